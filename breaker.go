@@ -0,0 +1,225 @@
+package pandascore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Requests are sent as usual
+	CircuitClosed CircuitState = 0
+
+	// Requests fail right away with ErrCircuitOpen
+	CircuitOpen CircuitState = 1
+
+	// A single trial request is sent to find out whether the PandaScore API has recovered
+	CircuitHalfOpen CircuitState = 2
+)
+
+// Returned instead of sending a request while the circuit of its endpoint group is open.
+var ErrCircuitOpen = errors.New("PandaScore error: circuit open")
+
+// State of the circuit of an endpoint group.
+type CircuitState byte
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker stops sending requests to the PandaScore API while it's failing, so callers fail fast instead of
+// piling up timeouts.
+//
+// Every endpoint group (the first part of the path after the game, eg. matches or leagues) has its own circuit. It
+// opens after a number of consecutive failures, being errors from the network, timeouts and responses with status
+// 429 or 5xx. After the cooldown a single trial request is let through: if it succeeds the circuit closes, otherwise
+// it opens again. While a circuit is open, the client serves responses from its cache regardless of their age.
+type CircuitBreaker struct {
+	mutex      sync.Mutex
+	threshold  int
+	thresholds map[string]int
+	cooldown   time.Duration
+	circuits   map[string]*circuit
+	now        func() time.Time
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	opened   time.Time
+	trial    bool
+}
+
+// Construct a new circuit breaker that opens the circuit of an endpoint group after the given number of consecutive
+// failures and tries again after the given cooldown. The threshold and cooldown must be larger than 0.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+	if cooldown <= 0 {
+		cooldown = time.Minute
+	}
+	return &CircuitBreaker{
+		threshold:  threshold,
+		thresholds: make(map[string]int),
+		cooldown:   cooldown,
+		circuits:   make(map[string]*circuit),
+		now:        time.Now,
+	}
+}
+
+// Protect the requests of the client with the given circuit breaker.
+func (c *Client) CircuitBreaker(breaker *CircuitBreaker) *Client {
+	c.circuitBreaker = breaker
+	return c
+}
+
+// Sets the number of consecutive failures after which the circuit of the given endpoint group opens, eg. a lower one
+// for matches than for the rest.
+func (b *CircuitBreaker) Threshold(group string, threshold int) *CircuitBreaker {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if threshold > 0 {
+		b.thresholds[group] = threshold
+	}
+	return b
+}
+
+// Returns the state of the circuit of the given endpoint group.
+func (b *CircuitBreaker) State(group string) CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.state(group)
+}
+
+// Returns the state of the circuits of all endpoint groups that have been requested, eg. for health checks.
+func (b *CircuitBreaker) States() map[string]CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	states := make(map[string]CircuitState, len(b.circuits))
+	for group := range b.circuits {
+		states[group] = b.state(group)
+	}
+	return states
+}
+
+// An open circuit is reported as half-open once its cooldown has passed, even before the trial request is sent.
+func (b *CircuitBreaker) state(group string) CircuitState {
+	current, ok := b.circuits[group]
+	if !ok {
+		return CircuitClosed
+	}
+	if current.state == CircuitOpen && !b.now().Before(current.opened.Add(b.cooldown)) {
+		return CircuitHalfOpen
+	}
+	return current.state
+}
+
+// Returns ErrCircuitOpen if no request of the given endpoint group may be sent right now, without claiming the trial
+// request of a half-open circuit, so requests can fail fast before waiting for the rate limiter.
+func (b *CircuitBreaker) check(group string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state(group) {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.circuits[group].trial {
+			return ErrCircuitOpen
+		}
+	}
+	return nil
+}
+
+// Returns ErrCircuitOpen if no request of the given endpoint group may be sent right now. Every request that is
+// allowed must be followed by a call to record.
+func (b *CircuitBreaker) allow(group string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	current, ok := b.circuits[group]
+	if !ok {
+		current = &circuit{}
+		b.circuits[group] = current
+	}
+
+	switch b.state(group) {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if current.trial {
+			return ErrCircuitOpen
+		}
+		current.state = CircuitHalfOpen
+		current.trial = true
+	}
+	return nil
+}
+
+// Record the outcome of a request of the given endpoint group.
+func (b *CircuitBreaker) record(group string, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	current := b.circuits[group]
+	current.trial = false
+	switch {
+	case !isFailure(err):
+		if err == nil || !errors.Is(err, context.Canceled) && !errors.Is(err, ErrRateLimited) {
+			current.state = CircuitClosed
+			current.failures = 0
+		}
+	case current.state == CircuitHalfOpen:
+		current.state = CircuitOpen
+		current.opened = b.now()
+	default:
+		current.failures++
+		threshold, ok := b.thresholds[group]
+		if !ok {
+			threshold = b.threshold
+		}
+		if current.failures >= threshold {
+			current.state = CircuitOpen
+			current.opened = b.now()
+		}
+	}
+}
+
+// Returns whether the given error means the PandaScore API is failing. Errors about the request itself (eg. resources
+// that don't exist or an invalid access token) mean the API is working fine, and requests that were cancelled or
+// stopped by the rate limiter never reached it.
+func isFailure(err error) bool {
+	var pandaScoreError *PandaScoreError
+	switch {
+	case err == nil, errors.Is(err, ErrNotFound), errors.Is(err, context.Canceled), errors.Is(err, ErrRateLimited):
+		return false
+	case errors.As(err, &pandaScoreError) && pandaScoreError.StatusCode > 0:
+		return pandaScoreError.StatusCode == http.StatusTooManyRequests || pandaScoreError.StatusCode >= 500
+	default:
+		return true
+	}
+}
+
+// Returns the endpoint group of the request, being the first part of its path after the game. Requests for all games
+// may still start with one, eg. csgo/matches/running sent through the proxy.
+func (r *Request) group() string {
+	segments := strings.SplitN(strings.Trim(r.path, "/"), "/", 3)
+	if len(segments) > 1 && Game(segments[0]).IsValid() {
+		return segments[1]
+	}
+	return segments[0]
+}
@@ -0,0 +1,247 @@
+package pandascore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func mockServerError(path string, times int) {
+	gock.New("https://api.pandascore.co" + path).
+		Times(times).
+		Reply(http.StatusInternalServerError).
+		JSON(map[string]string{"error": "Internal server error"})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	defer gock.Off()
+	mockServerError("/csgo/matches/running", 2)
+
+	breaker := NewCircuitBreaker(2, time.Minute)
+	client := New().CircuitBreaker(breaker)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+		assert.EqualError(t, err, "PandaScore error: Internal server error")
+	}
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, CircuitOpen, breaker.State("matches"))
+
+	_, err := client.Request(CSGO, "matches/upcoming").Get(new([]Match))
+	assert.Equal(t, ErrCircuitOpen, err, "Expected all requests of the endpoint group to fail fast")
+	assert.Equal(t, map[string]CircuitState{"matches": CircuitOpen}, breaker.States())
+	assert.Equal(t, CircuitClosed, breaker.State("leagues"))
+}
+
+func TestCircuitBreaker_halfOpen(t *testing.T) {
+	defer gock.Off()
+	mockServerError("/csgo/matches/running", 2)
+
+	now := time.Date(2020, 4, 4, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	client := New().CircuitBreaker(breaker)
+
+	_, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.NotNil(t, err)
+	assert.Equal(t, CircuitOpen, breaker.State("matches"))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.State("matches"))
+	_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.NotNil(t, err)
+	assert.Equal(t, CircuitOpen, breaker.State("matches"), "Expected a failed trial request to open the circuit again")
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-running.json")
+	now = now.Add(time.Minute)
+	_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State("matches"))
+	assert.True(t, gock.IsDone())
+}
+
+func TestCircuitBreaker_singleTrial(t *testing.T) {
+	now := time.Date(2020, 4, 4, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	assert.Nil(t, breaker.allow("matches"))
+	breaker.record("matches", errors.New("connection refused"))
+	now = now.Add(time.Minute)
+
+	assert.Nil(t, breaker.allow("matches"))
+	assert.Equal(t, ErrCircuitOpen, breaker.allow("matches"), "Expected only one trial request while half-open")
+	breaker.record("matches", context.Canceled)
+	assert.Nil(t, breaker.allow("matches"), "Expected a new trial request if the previous one never reached the API")
+}
+
+func TestCircuitBreaker_scheduler(t *testing.T) {
+	now := time.Date(2020, 4, 4, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	scheduler := NewScheduler(1, time.Hour)
+	client := New().CircuitBreaker(breaker).Scheduler(scheduler)
+	assert.Nil(t, scheduler.Wait(context.Background(), PriorityInteractive))
+
+	assert.Nil(t, breaker.allow("matches"))
+	breaker.record("matches", errors.New("connection refused"))
+	now = now.Add(time.Minute)
+
+	// Hide the deadline from the scheduler, as if the request got overtaken in the queue after all
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		_, err := client.Request(CSGO, "matches/running").Context(withoutDeadline{ctx}).Get(new([]Match))
+		done <- err
+	}()
+	assert.Eventually(t, func() bool { return scheduler.Queued(PriorityInteractive) == 1 }, time.Second, time.Millisecond)
+	assert.Nil(t, breaker.allow("matches"), "Expected a queued request not to hold the trial request")
+	breaker.record("matches", context.Canceled)
+
+	assert.Equal(t, context.DeadlineExceeded, <-done)
+	assert.Equal(t, CircuitHalfOpen, breaker.State("matches"), "Expected running out of time in the queue not to count as a failure")
+}
+
+func TestCircuitBreaker_rateLimit(t *testing.T) {
+	defer gock.Off()
+	mockServerError("/csgo/matches/running", 1)
+
+	limiter := NewRateLimiter(2, time.Hour)
+	client := New().CircuitBreaker(NewCircuitBreaker(1, time.Minute))
+	client.rateLimiter = limiter
+
+	_, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.NotNil(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+		assert.Equal(t, ErrCircuitOpen, err, "Expected requests to fail fast while the circuit is open")
+	}
+	assert.Equal(t, 1, limiter.Remaining(), "Expected rejected requests not to use up the quota")
+	assert.True(t, gock.IsDone())
+}
+
+func TestCircuitBreaker_refund(t *testing.T) {
+	now := time.Date(2020, 4, 4, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	scheduler := NewScheduler(1, 200*time.Millisecond)
+	client := New().CircuitBreaker(breaker).Scheduler(scheduler)
+	assert.Nil(t, scheduler.Wait(context.Background(), PriorityInteractive))
+
+	assert.Nil(t, breaker.allow("matches"))
+	breaker.record("matches", errors.New("connection refused"))
+	now = now.Add(time.Minute)
+
+	done := make(chan error)
+	go func() {
+		_, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+		done <- err
+	}()
+	assert.Eventually(t, func() bool { return scheduler.Queued(PriorityInteractive) == 1 }, time.Second, time.Millisecond)
+	assert.Nil(t, breaker.allow("matches"), "Expected the trial request to be claimed while the other one is queued")
+
+	assert.Equal(t, ErrCircuitOpen, <-done)
+	assert.Equal(t, 1, scheduler.Remaining(PriorityInteractive), "Expected the quota to be given back")
+}
+
+type withoutDeadline struct {
+	context.Context
+}
+
+func (withoutDeadline) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func TestCircuitBreaker_Threshold(t *testing.T) {
+	defer gock.Off()
+	mockServerError("/csgo/matches/running", 1)
+	mockServerError("/csgo/leagues", 1)
+
+	breaker := NewCircuitBreaker(3, time.Minute).Threshold("matches", 1)
+	client := New().CircuitBreaker(breaker)
+
+	_, _ = client.Request(CSGO, "matches/running").Get(new([]Match))
+	_, _ = client.Request(CSGO, "leagues").Get(new([]League))
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, CircuitOpen, breaker.State("matches"))
+	assert.Equal(t, CircuitClosed, breaker.State("leagues"))
+}
+
+func TestCircuitBreaker_notFound(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.pandascore.co/matches/1").
+		Times(2).
+		Reply(http.StatusNotFound)
+
+	breaker := NewCircuitBreaker(1, time.Minute)
+	client := New().CircuitBreaker(breaker)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetMatch("1")
+		assert.Equal(t, ErrNotFound, err)
+	}
+	assert.Equal(t, CircuitClosed, breaker.State("matches"), "Expected requests answered by the API not to count as failures")
+}
+
+func TestCircuitBreaker_cache(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-running.json")
+	mockServerError("/csgo/matches/running", 1)
+
+	cache := NewMemoryCache(10)
+	client := New().Cache(cache, time.Minute).CircuitBreaker(NewCircuitBreaker(1, time.Minute))
+
+	_, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+
+	key := "https://api.pandascore.co/csgo/matches/running"
+	entry, _ := cache.Get(key)
+	entry.Stored = entry.Stored.Add(-time.Hour)
+	cache.Set(key, entry)
+
+	_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.NotNil(t, err, "Expected the request that opens the circuit to fail")
+
+	matches := new([]Match)
+	_, err = client.Request(CSGO, "matches/running").Get(matches)
+	assert.Nil(t, err)
+	assert.Len(t, *matches, 4, "Expected the expired response to be served while the circuit is open")
+	assert.True(t, gock.IsDone())
+}
+
+func TestRequest_group(t *testing.T) {
+	client := New()
+	assert.Equal(t, "matches", client.Request(CSGO, "matches/running").group())
+	assert.Equal(t, "matches", client.RequestAllGames("matches/1").group())
+	assert.Equal(t, "matches", client.RequestAllGames("csgo/matches/running").group(), "Expected the game to be skipped")
+	assert.Equal(t, "leagues", client.RequestAllGames("/dota2/leagues").group())
+	assert.Equal(t, "csgo", client.RequestAllGames("csgo").group())
+}
+
+func TestIsFailure(t *testing.T) {
+	assert.False(t, isFailure(nil))
+	assert.False(t, isFailure(ErrNotFound))
+	assert.False(t, isFailure(ErrRateLimited))
+	assert.False(t, isFailure(context.Canceled))
+	assert.False(t, isFailure(&PandaScoreError{Message: "Token is invalid", StatusCode: http.StatusForbidden}))
+	assert.True(t, isFailure(&PandaScoreError{Message: "Too many requests", StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, isFailure(&PandaScoreError{Message: "Bad gateway", StatusCode: http.StatusBadGateway}))
+	assert.True(t, isFailure(context.DeadlineExceeded))
+	assert.True(t, isFailure(errors.New("connection refused")))
+}
+
+func TestCircuitState_String(t *testing.T) {
+	assert.Equal(t, "closed", CircuitClosed.String())
+	assert.Equal(t, "open", CircuitOpen.String())
+	assert.Equal(t, "half-open", CircuitHalfOpen.String())
+}
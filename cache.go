@@ -0,0 +1,101 @@
+package pandascore
+
+import (
+	"sync"
+	"time"
+)
+
+// Default maximum number of responses kept by a MemoryCache
+const DefaultCacheSize = 1000
+
+// Cache keeps the bodies of successful responses, keyed by request URL, so identical requests aren't sent to the
+// PandaScore API again while the cached response is fresh.
+type Cache interface {
+	// Returns the entry stored with the given key, if there is one
+	Get(key string) (CacheEntry, bool)
+
+	// Stores the given entry with the given key
+	Set(key string, entry CacheEntry)
+}
+
+// CacheEntry is a single cached response along with the time it was stored.
+type CacheEntry struct {
+	Body     []byte
+	Response Response
+	Stored   time.Time
+}
+
+// Cache successful responses in the given cache and use them for identical requests for the given amount of time.
+func (c *Client) Cache(cache Cache, ttl time.Duration) *Client {
+	c.cache = cache
+	c.cacheTTL = ttl
+	return c
+}
+
+// Returns the cached body and response for the given key if they are still fresh.
+func (c *Client) cached(key string) ([]byte, Response, bool) {
+	if c.cache == nil || c.cacheTTL <= 0 {
+		return nil, Response{}, false
+	}
+
+	entry, ok := c.cache.Get(key)
+	if !ok || time.Since(entry.Stored) > c.cacheTTL {
+		return nil, Response{}, false
+	}
+	return entry.Body, entry.Response, true
+}
+
+// Stores the given body and response in the cache, if the client has one.
+func (c *Client) store(key string, body []byte, response Response) {
+	if c.cache != nil {
+		c.cache.Set(key, CacheEntry{Body: body, Response: response, Stored: time.Now()})
+	}
+}
+
+// MemoryCache keeps a limited number of responses in memory. When it's full, the oldest response is evicted.
+type MemoryCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[string]CacheEntry
+}
+
+// Construct a new, empty in-memory cache that keeps at most the given number of responses. The size must be larger
+// than 0; DefaultCacheSize is used otherwise.
+func NewMemoryCache(size int) *MemoryCache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &MemoryCache{size: size, entries: make(map[string]CacheEntry)}
+}
+
+func (c *MemoryCache) Get(key string) (CacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *MemoryCache) Set(key string, entry CacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		oldestKey := ""
+		var oldest time.Time
+		for key, entry := range c.entries {
+			if len(oldestKey) == 0 || entry.Stored.Before(oldest) {
+				oldestKey, oldest = key, entry.Stored
+			}
+		}
+		delete(c.entries, oldestKey)
+	}
+	c.entries[key] = entry
+}
+
+// Returns the number of cached responses.
+func (c *MemoryCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.entries)
+}
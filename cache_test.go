@@ -0,0 +1,94 @@
+package pandascore
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClient_Cache(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-running.json").
+		SetHeader("X-Total", "4")
+
+	cache := NewMemoryCache(10)
+	client := New().Cache(cache, time.Minute)
+
+	first := new([]Match)
+	response, err := client.Request(CSGO, "matches/running").Get(first)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, response.TotalResults)
+
+	second := new([]Match)
+	response, err = client.Request(CSGO, "matches/running").Get(second)
+	assert.Nil(t, err, "Expected the second request to be served from the cache")
+	assert.Equal(t, 4, response.TotalResults)
+	assert.Equal(t, *first, *second)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestClient_Cache_expired(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Times(2).
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-running.json")
+
+	cache := NewMemoryCache(10)
+	client := New().Cache(cache, time.Minute)
+
+	_, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+
+	key := "https://api.pandascore.co/csgo/matches/running"
+	entry, ok := cache.Get(key)
+	assert.True(t, ok)
+	entry.Stored = entry.Stored.Add(-2 * time.Minute)
+	cache.Set(key, entry)
+
+	_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone(), "Expected an expired response to be fetched again")
+}
+
+func TestClient_Cache_errorsAreNotCached(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/matches/559177").
+		Reply(http.StatusNotFound).
+		File("testdata/error-not-found.json")
+
+	cache := NewMemoryCache(10)
+	_, err := New().Cache(cache, time.Minute).GetMatch("559177")
+
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestMemoryCache_evictsOldest(t *testing.T) {
+	cache := NewMemoryCache(2)
+	now := time.Now()
+
+	cache.Set("a", CacheEntry{Stored: now.Add(-time.Minute)})
+	cache.Set("b", CacheEntry{Stored: now})
+	cache.Set("a", CacheEntry{Stored: now.Add(-2 * time.Minute)})
+	assert.Equal(t, 2, cache.Len(), "Expected replacing an entry not to evict another one")
+
+	cache.Set("c", CacheEntry{Stored: now})
+	assert.Equal(t, 2, cache.Len())
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	_, ok = cache.Get("b")
+	assert.True(t, ok)
+}
+
+func TestNewMemoryCache_defaultSize(t *testing.T) {
+	assert.Equal(t, DefaultCacheSize, NewMemoryCache(0).size)
+}
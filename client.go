@@ -4,9 +4,12 @@
 package pandascore
 
 import (
+	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
+	"time"
 )
 
 const (
@@ -23,6 +26,24 @@ type Client struct {
 	httpClient  *http.Client
 	accessToken string
 	filter      string
+
+	schemaDriftDetection bool
+	schemaDriftHandler   func(SchemaDriftReport)
+
+	cache          Cache
+	cacheTTL       time.Duration
+	rateLimiter    *RateLimiter
+	scheduler      *Scheduler
+	circuitBreaker *CircuitBreaker
+	inFlight       inFlight
+
+	serveStale         bool
+	staleMaxAge        time.Duration
+	staleRetryInterval time.Duration
+	revalidator        revalidator
+
+	hourlyQuota int
+	usage       usage
 }
 
 // Construct a new PandaScore client with the default URL.
@@ -34,6 +55,10 @@ func New() *Client {
 		httpClient:  http.DefaultClient,
 		baseURL:     &url.URL{Scheme: "https", Host: BaseURL},
 		accessToken: os.Getenv(AccessTokenEnvironmentVariable),
+
+		staleRetryInterval: time.Second,
+		hourlyQuota:        DefaultHourlyQuota,
+		usage:              usage{since: time.Now()},
 	}
 
 	return c
@@ -45,6 +70,26 @@ func (c *Client) AccessToken(accessToken string) *Client {
 	return c
 }
 
+// Sets the base URL of the PandaScore API, eg. to send requests through a proxy. Invalid URLs are ignored.
+func (c *Client) BaseURL(baseURL string) *Client {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || len(parsed.Scheme) == 0 || len(parsed.Host) == 0 {
+		log.Printf("⚠ warning: ignoring invalid PandaScore base URL '%s'", baseURL)
+		return c
+	}
+	if !strings.HasSuffix(parsed.Path, "/") {
+		parsed.Path += "/"
+	}
+	c.baseURL = parsed
+	return c
+}
+
+// Sets the HTTP client used to send requests, eg. to configure timeouts.
+func (c *Client) HTTPClient(httpClient *http.Client) *Client {
+	c.httpClient = httpClient
+	return c
+}
+
 // Construct a new request for the given game with the given path.
 func (c *Client) Request(game Game, path string) *Request {
 	return &Request{
@@ -53,3 +98,12 @@ func (c *Client) Request(game Game, path string) *Request {
 		path:   path,
 	}
 }
+
+// Construct a new request with the given path that isn't specific to a single game, like the series of a league.
+func (c *Client) RequestAllGames(path string) *Request {
+	return &Request{
+		client:   c,
+		allGames: true,
+		path:     path,
+	}
+}
@@ -1,8 +1,10 @@
 package pandascore
 
 import (
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,3 +43,16 @@ func TestClient_Request(t *testing.T) {
 	assert.Equal(t, CSGO, result.game)
 	assert.Equal(t, "/path", result.path)
 }
+
+func TestClient_BaseURL(t *testing.T) {
+	client := New().BaseURL("http://localhost:8080/pandascore")
+	assert.Equal(t, "http://localhost:8080/pandascore/", client.baseURL.String())
+
+	client.BaseURL("not a URL")
+	assert.Equal(t, "http://localhost:8080/pandascore/", client.baseURL.String(), "Expected invalid URLs to be ignored")
+}
+
+func TestClient_HTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Second}
+	assert.Equal(t, httpClient, New().HTTPClient(httpClient).httpClient)
+}
@@ -0,0 +1,65 @@
+// Command pandascore-proxy is an HTTP server with the same URLs as the PandaScore API, which lets a number of internal
+// services share a single PandaScore access token and quota.
+//
+// Every request is forwarded upstream with the access token of the proxy, through a cache and a rate limiter.
+// Identical requests that arrive while one is in flight share its result. Services authenticate with their own key,
+// exactly like they would with PandaScore, so they only need to change the base URL of their client:
+//
+//	client := pandascore.New().AccessToken(serviceKey).BaseURL("http://pandascore-proxy:8080")
+//
+// The keys of all services are read from a file with a service name and key per line.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to listen on")
+	upstream := flag.String("upstream", "https://"+pandascore.BaseURL, "base URL of the PandaScore API")
+	token := flag.String("token", "", "PandaScore access token; defaults to $"+pandascore.AccessTokenEnvironmentVariable)
+	keysFile := flag.String("keys", "", "file with the name and key of every service, one per line")
+	cacheTTL := flag.Duration("cache-ttl", 30*time.Second, "time responses are cached; 0 disables caching")
+	cacheSize := flag.Int("cache-size", pandascore.DefaultCacheSize, "maximum number of cached responses")
+	serveStale := flag.Duration("serve-stale", 0, "maximum age of cached responses served when PandaScore fails; 0 disables serving them")
+	rateLimit := flag.Int("rate-limit", 1000, "maximum number of upstream requests per rate interval")
+	rateInterval := flag.Duration("rate-interval", time.Hour, "interval of the rate limit")
+	circuitThreshold := flag.Int("circuit-threshold", 5, "consecutive upstream failures after which requests fail fast; 0 disables the circuit breaker")
+	circuitCooldown := flag.Duration("circuit-cooldown", 30*time.Second, "time before requests are tried again after the circuit opened")
+	timeout := flag.Duration("timeout", 30*time.Second, "maximum time to answer a request, including waiting for the rate limit")
+	flag.Parse()
+
+	if len(*keysFile) == 0 {
+		log.Fatal("the -keys flag is required")
+	}
+	keys, err := readKeysFile(*keysFile)
+	if err != nil {
+		log.Fatalf("failed to read keys: %s", err)
+	}
+
+	client := pandascore.New().
+		BaseURL(*upstream).
+		HTTPClient(&http.Client{Timeout: *timeout}).
+		Cache(pandascore.NewMemoryCache(*cacheSize), *cacheTTL).
+		RateLimit(*rateLimit, *rateInterval)
+	if *serveStale > 0 {
+		client.ServeStale(*serveStale)
+	}
+	if *circuitThreshold > 0 {
+		client.CircuitBreaker(pandascore.NewCircuitBreaker(*circuitThreshold, *circuitCooldown))
+	}
+	if len(*token) > 0 {
+		client.AccessToken(*token)
+	} else if len(os.Getenv(pandascore.AccessTokenEnvironmentVariable)) == 0 {
+		log.Fatalf("no PandaScore access token; set $%s or use -token", pandascore.AccessTokenEnvironmentVariable)
+	}
+
+	log.Printf("forwarding requests of %d services to %s on %s", len(keys), *upstream, *listen)
+	log.Fatal(http.ListenAndServe(*listen, newProxy(client, keys, *timeout)))
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// Proxy forwards requests with the same URLs as the PandaScore API to the upstream API, using a single client (and
+// thus a single access token, cache and rate limiter) for all callers. Callers authenticate with their own key, either
+// as bearer token or as token query parameter, just like they would with PandaScore.
+type proxy struct {
+	client  *pandascore.Client
+	keys    map[string]string
+	timeout time.Duration
+}
+
+// Construct a new proxy that forwards requests with the given client. Keys maps the keys of callers to their names.
+// Requests that can't be answered within the given timeout, eg. because of the rate limiter, fail.
+func newProxy(client *pandascore.Client, keys map[string]string, timeout time.Duration) *proxy {
+	return &proxy{client: client, keys: keys, timeout: timeout}
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	caller, ok := p.authenticate(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Invalid or missing key")
+		return
+	}
+
+	path := strings.Trim(r.URL.EscapedPath(), "/")
+	if len(path) == 0 {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	query := r.URL.Query()
+	query.Del("token")
+
+	body, response, err := p.forward(caller, path, query)
+	status := http.StatusOK
+	if err != nil {
+		status = writeUpstreamError(w, err)
+	} else {
+		header := w.Header()
+		header.Set("Content-Type", "application/json; charset=utf-8")
+		header.Set("X-Page", strconv.Itoa(response.CurrentPage))
+		header.Set("X-Per-Page", strconv.Itoa(response.ResultsPerPage))
+		header.Set("X-Total", strconv.Itoa(response.TotalResults))
+		if response.Stale {
+			header.Set("Age", strconv.Itoa(int(response.Age.Seconds())))
+		}
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(body)
+		}
+	}
+	log.Printf("%s %s?%s %d", caller, path, query.Encode(), status)
+}
+
+// Returns the name of the caller whose key is used in the given request.
+func (p *proxy) authenticate(r *http.Request) (string, bool) {
+	key := r.URL.Query().Get("token")
+	if authorization := r.Header.Get("Authorization"); strings.HasPrefix(authorization, "Bearer ") {
+		key = strings.TrimPrefix(authorization, "Bearer ")
+	}
+	if len(key) == 0 {
+		return "", false
+	}
+	caller, ok := p.keys[key]
+	return caller, ok
+}
+
+// Forward the request with the given path and query upstream, tagged with the name of the caller so its usage of the
+// quota is counted separately. The client counts it per endpoint with IDs and slugs replaced and puts anything beyond
+// pandascore.MaxUsageEndpoints under pandascore.UsageOther, so callers can't grow the counters by requesting arbitrary
+// paths. Identical requests that arrive while it's in flight share its result, as the client
+// only sends one of them upstream.
+func (p *proxy) forward(caller string, path string, query url.Values) ([]byte, pandascore.Response, error) {
+	request := p.client.RequestAllGames(path).Tag(caller)
+	for name, values := range query {
+		request.Param(name, values...)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	return request.Context(ctx).GetRaw()
+}
+
+// Write the given upstream error with a matching status code and return that status code.
+func writeUpstreamError(w http.ResponseWriter, err error) int {
+	var pandaScoreError *pandascore.PandaScoreError
+	switch {
+	case errors.Is(err, pandascore.ErrNotFound):
+		return writeError(w, http.StatusNotFound, "Not found")
+	case errors.Is(err, pandascore.ErrRateLimited):
+		return writeError(w, http.StatusTooManyRequests, "Rate limit reached, try again later")
+	case errors.Is(err, pandascore.ErrCircuitOpen):
+		return writeError(w, http.StatusServiceUnavailable, "PandaScore API unavailable, try again later")
+	case errors.Is(err, context.DeadlineExceeded):
+		return writeError(w, http.StatusGatewayTimeout, "PandaScore API didn't respond in time")
+	case errors.As(err, &pandaScoreError) && pandaScoreError.StatusCode > 0:
+		// Authentication errors are about the access token of the proxy, not about the key of the caller
+		if pandaScoreError.StatusCode == http.StatusUnauthorized || pandaScoreError.StatusCode == http.StatusForbidden {
+			log.Printf("PandaScore rejected the access token of the proxy: %s", pandaScoreError.Message)
+			return writeError(w, http.StatusBadGateway, "PandaScore rejected the access token of the proxy")
+		}
+		return writeError(w, pandaScoreError.StatusCode, pandaScoreError.Message)
+	default:
+		log.Printf("PandaScore request failed: %s", err)
+		return writeError(w, http.StatusBadGateway, "PandaScore API unavailable")
+	}
+}
+
+// Write an error in the same format as PandaScore and return its status code.
+func writeError(w http.ResponseWriter, status int, message string) int {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+	return status
+}
+
+// Read the keys of all callers from the given reader, one caller per line as name followed by key. Empty lines and
+// lines starting with # are ignored.
+func readKeys(r io.Reader) (map[string]string, error) {
+	keys := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a name and a key", line)
+		}
+		if _, ok := keys[fields[1]]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key for %s", line, fields[0])
+		}
+		keys[fields[1]] = fields[0]
+	}
+	return keys, scanner.Err()
+}
+
+// Read the keys of all callers from the file at the given path.
+func readKeysFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readKeys(file)
+}
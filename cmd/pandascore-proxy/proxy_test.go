@@ -0,0 +1,238 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// Fake PandaScore API that serves the test data and counts the requests it receives.
+type fakeUpstream struct {
+	*httptest.Server
+	requests int32
+	release  chan struct{}
+}
+
+func newFakeUpstream(t *testing.T) *fakeUpstream {
+	upstream := &fakeUpstream{}
+	upstream.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream.requests, 1)
+		if upstream.release != nil {
+			<-upstream.release
+		}
+
+		if r.Header.Get("Authorization") != "Bearer upstream-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":"Token is invalid"}`))
+			return
+		}
+
+		var file string
+		switch r.URL.Path {
+		case "/csgo/matches/running":
+			file = "csgo-matches-running.json"
+		case "/csgo/leagues":
+			file = "csgo-leagues-esl.json"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"Not found"}`))
+			return
+		}
+		content, err := ioutil.ReadFile("../../testdata/" + file)
+		assert.Nil(t, err)
+		perPage := r.URL.Query().Get("page[size]")
+		if len(perPage) == 0 {
+			perPage = "50"
+		}
+		w.Header().Set("X-Page", "1")
+		w.Header().Set("X-Per-Page", perPage)
+		w.Header().Set("X-Total", "4")
+		_, _ = w.Write(content)
+	}))
+	return upstream
+}
+
+func (u *fakeUpstream) count() int {
+	return int(atomic.LoadInt32(&u.requests))
+}
+
+// Starts a proxy in front of the given upstream and returns it along with a client for the given key.
+func newTestProxy(upstream *fakeUpstream, upstreamClient *pandascore.Client) (*proxy, *httptest.Server) {
+	upstreamClient.BaseURL(upstream.URL).AccessToken("upstream-token")
+	p := newProxy(upstreamClient, map[string]string{"dashboard-key": "dashboard", "backfill-key": "backfill"}, time.Second)
+	return p, httptest.NewServer(p)
+}
+
+func TestProxy_endToEnd(t *testing.T) {
+	upstream := newFakeUpstream(t)
+	defer upstream.Close()
+	p, server := newTestProxy(upstream, pandascore.New().Cache(pandascore.NewMemoryCache(10), time.Minute))
+	defer server.Close()
+
+	dashboard := pandascore.New().AccessToken("dashboard-key").BaseURL(server.URL)
+	matches, err := dashboard.GetAllRunningMatches(pandascore.CSGO)
+	assert.Nil(t, err)
+	assert.Len(t, matches, 4)
+
+	backfill := pandascore.New().AccessToken("backfill-key").BaseURL(server.URL)
+	matches, err = backfill.GetAllRunningMatches(pandascore.CSGO)
+	assert.Nil(t, err)
+	assert.Len(t, matches, 4)
+	assert.Equal(t, 1, upstream.count(), "Expected the second request to be served from the cache")
+
+	leagues := new([]pandascore.League)
+	response, err := dashboard.Request(pandascore.CSGO, "leagues").PageSize(7).Get(leagues)
+	assert.Nil(t, err)
+	assert.Len(t, *leagues, 1)
+	assert.Equal(t, 7, response.ResultsPerPage, "Expected the query and paging headers to be forwarded")
+	assert.Equal(t, 4, response.TotalResults)
+	assert.Equal(t, 1, response.CurrentPage)
+
+	_, err = dashboard.GetMatch("1")
+	assert.Equal(t, pandascore.ErrNotFound, err)
+
+	usage := p.client.Usage()
+	assert.Len(t, usage.Counters, 4)
+	assert.Equal(t, "backfill", usage.Counters[0].Tag, "Expected the usage to be counted per caller")
+	assert.Equal(t, 0, usage.Counters[0].Requests, "Expected the cached response to be served without a request")
+	assert.Equal(t, 1, usage.Counters[0].Pages)
+}
+
+func TestProxy_authentication(t *testing.T) {
+	upstream := newFakeUpstream(t)
+	defer upstream.Close()
+	_, server := newTestProxy(upstream, pandascore.New())
+	defer server.Close()
+
+	_, err := pandascore.New().AccessToken("unknown-key").BaseURL(server.URL).GetAllRunningMatches(pandascore.CSGO)
+	assert.EqualError(t, err, "PandaScore error: Invalid or missing key")
+
+	response, err := http.Get(server.URL + "/csgo/leagues?token=backfill-key")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode, "Expected the key to be accepted as query parameter")
+
+	response, err = http.Post(server.URL+"/csgo/leagues?token=backfill-key", "application/json", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, response.StatusCode)
+	assert.Equal(t, 1, upstream.count())
+}
+
+func TestProxy_upstreamTokenRejected(t *testing.T) {
+	upstream := newFakeUpstream(t)
+	defer upstream.Close()
+	p, server := newTestProxy(upstream, pandascore.New())
+	defer server.Close()
+	p.client.AccessToken("revoked-token")
+
+	response, err := http.Get(server.URL + "/csgo/leagues?token=backfill-key")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+}
+
+func TestProxy_coalescing(t *testing.T) {
+	upstream := newFakeUpstream(t)
+	upstream.release = make(chan struct{})
+	defer upstream.Close()
+	p, _ := newTestProxy(upstream, pandascore.New())
+	var arrived int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&arrived, 1)
+		p.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	// Every request uses its own client, like separate services would, so they aren't coalesced by the client already
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client := pandascore.New().AccessToken("dashboard-key").BaseURL(server.URL)
+			matches, err := client.GetAllRunningMatches(pandascore.CSGO)
+			assert.Nil(t, err)
+			assert.Len(t, matches, 4)
+		}()
+	}
+
+	// Only release the upstream response once all requests have reached the proxy
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&arrived) == 5 && upstream.count() == 1
+	}, time.Second, time.Millisecond)
+	close(upstream.release)
+	wg.Wait()
+
+	assert.Equal(t, 1, upstream.count(), "Expected the requests to share a single upstream request")
+	usage := p.client.Usage()
+	assert.Len(t, usage.Counters, 1)
+	assert.Equal(t, 1, usage.Counters[0].Requests)
+	assert.Equal(t, 5, usage.Counters[0].Pages)
+}
+
+func TestProxy_rateLimit(t *testing.T) {
+	upstream := newFakeUpstream(t)
+	defer upstream.Close()
+	_, server := newTestProxy(upstream, pandascore.New().RateLimit(1, time.Hour))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/csgo/leagues?token=backfill-key")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response, err = http.Get(server.URL + "/csgo/matches/running?token=backfill-key")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, 1, upstream.count())
+}
+
+func TestReadKeys(t *testing.T) {
+	keys, err := readKeys(strings.NewReader("# services\ndashboard dashboard-key\n\n  backfill   backfill-key  \n"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"dashboard-key": "dashboard", "backfill-key": "backfill"}, keys)
+
+	_, err = readKeys(strings.NewReader("dashboard\n"))
+	assert.EqualError(t, err, "line 1: expected a name and a key")
+
+	_, err = readKeys(strings.NewReader("dashboard key\nbackfill key\n"))
+	assert.EqualError(t, err, "line 2: duplicate key for backfill")
+}
+
+func TestProxy_circuitOpen(t *testing.T) {
+	upstream := newFakeUpstream(t)
+	_, server := newTestProxy(upstream, pandascore.New().CircuitBreaker(pandascore.NewCircuitBreaker(1, time.Minute)))
+	defer server.Close()
+	upstream.Close()
+
+	response, err := http.Get(server.URL + "/csgo/leagues?token=backfill-key")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+
+	response, err = http.Get(server.URL + "/csgo/leagues?token=backfill-key")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode, "Expected requests to fail fast once the circuit is open")
+}
+
+func TestProxy_usageEndpoints(t *testing.T) {
+	upstream := newFakeUpstream(t)
+	defer upstream.Close()
+	p, server := newTestProxy(upstream, pandascore.New())
+	defer server.Close()
+
+	for _, path := range []string{"/csgo/teams/astralis", "/csgo/teams/natus-vincere", "/csgo/teams/1"} {
+		response, err := http.Get(server.URL + path + "?token=dashboard-key")
+		assert.Nil(t, err)
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	}
+
+	usage := p.client.Usage()
+	assert.Equal(t, []pandascore.UsageCounters{{Tag: "dashboard", Endpoint: "csgo/teams/:id", Requests: 3, Errors: 3}},
+		usage.Counters, "Expected the teams to be counted together regardless of slug or ID")
+}
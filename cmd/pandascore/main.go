@@ -0,0 +1,73 @@
+// Command pandascore queries the PandaScore API from the command line.
+//
+// Usage:
+//
+//	pandascore leagues [flags]
+//	pandascore series [flags]
+//	pandascore matches upcoming|running|past [flags]
+//	pandascore teams [flags]
+//	pandascore players [flags]
+//	pandascore watch [flags]
+//
+// The PandaScore access token is read from the PANDASCORE_ACCESS_TOKEN environment variable or the -token flag. Run
+// any command with -h to list its flags.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+const usage = `Usage:
+  pandascore leagues [flags]
+  pandascore series [flags]
+  pandascore matches upcoming|running|past [flags]
+  pandascore teams [flags]
+  pandascore players [flags]
+  pandascore watch [flags]
+
+Run a command with -h to list its flags.
+`
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr, pandascore.New())
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "pandascore:", err)
+		os.Exit(1)
+	}
+}
+
+// Run the command with the given arguments (without the program name), writing its output to stdout and usage
+// information to stderr.
+func run(args []string, stdout io.Writer, stderr io.Writer, client *pandascore.Client) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		return flag.ErrHelp
+	}
+
+	name, args := args[0], args[1:]
+	if name == "watch" {
+		return watch(args, stdout, stderr, client)
+	}
+	if name == "matches" {
+		if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+			return errors.New("matches requires one of upcoming, running or past")
+		}
+		name, args = name+" "+args[0], args[1:]
+	}
+
+	resource, ok := resources[name]
+	if !ok {
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown command %q", name)
+	}
+	return query(name, resource, args, stdout, stderr, client)
+}
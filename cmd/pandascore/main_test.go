@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func execute(args ...string) (string, error) {
+	stdout := new(bytes.Buffer)
+	err := run(args, stdout, new(bytes.Buffer), pandascore.New().AccessToken("test"))
+	return stdout.String(), err
+}
+
+func TestRun_leaguesTable(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		MatchParam("search[name]", "ESL").
+		MatchParam("page[size]", "10").
+		Reply(http.StatusOK).
+		File("../../testdata/csgo-leagues-esl.json")
+
+	output, err := execute("leagues", "-search", "name=ESL", "-page-size", "10")
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Contains(t, output, "MODIFIED")
+	assert.Contains(t, output, "ESL")
+}
+
+func TestRun_matchesJSONLines(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/dota2/matches/upcoming").
+		MatchParam("filter[league_id]", "4158").
+		MatchParam("range[begin_at]", "2020-04-01T00:00:00Z,2020-05-01T00:00:00Z").
+		MatchParam("sort", "-begin_at").
+		Reply(http.StatusOK).
+		File("../../testdata/csgo-matches-upcoming.json")
+
+	output, err := execute("matches", "upcoming", "-game", "dota2", "-output", "jsonl",
+		"-filter", "league_id=4158", "-range", "begin_at=2020-04-01T00:00:00Z,2020-05-01T00:00:00Z", "-sort", "-begin_at")
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	assert.Len(t, lines, 3)
+
+	match := pandascore.Match{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &match))
+	assert.NotZero(t, match.ID)
+}
+
+func TestRun_matchesCSV(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/past").
+		Reply(http.StatusOK).
+		File("../../testdata/csgo-matches-past.json")
+
+	output, err := execute("matches", "past", "-output", "csv")
+
+	assert.Nil(t, err)
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, records, 4)
+	assert.Equal(t, []string{"ID", "Name", "Status", "Scheduled at", "League", "Series", "Score"}, records[0])
+	assert.Equal(t, "finished", records[1][2])
+}
+
+func TestRun_json(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/series").
+		Reply(http.StatusOK).
+		File("../../testdata/csgo-series-running.json")
+
+	output, err := execute("series", "-output", "json")
+
+	assert.Nil(t, err)
+	var series []pandascore.Series
+	assert.Nil(t, json.Unmarshal([]byte(output), &series))
+	assert.NotEmpty(t, series)
+}
+
+func TestRun_invalidArguments(t *testing.T) {
+	_, err := execute()
+	assert.Equal(t, flag.ErrHelp, err)
+
+	_, err = execute("unknown")
+	assert.EqualError(t, err, `unknown command "unknown"`)
+
+	_, err = execute("matches")
+	assert.NotNil(t, err)
+
+	_, err = execute("matches", "later")
+	assert.EqualError(t, err, `unknown command "matches later"`)
+
+	_, err = execute("teams", "-game", "chess")
+	assert.EqualError(t, err, `invalid game "chess"`)
+
+	_, err = execute("teams", "-output", "xml")
+	assert.EqualError(t, err, `invalid output format "xml"`)
+
+	_, err = execute("teams", "-filter", "name")
+	assert.EqualError(t, err, `invalid filter "name", expected field=value`)
+
+	_, err = execute("teams", "-range", "begin_at=2020")
+	assert.EqualError(t, err, `invalid range "begin_at=2020", expected field=lower,upper`)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Renders the result of a query, which is always a pointer to a slice of models.
+type renderer func(w io.Writer, resource resource, result interface{}) error
+
+var renderers = map[string]renderer{
+	"table": renderTable,
+	"json":  renderJSON,
+	"jsonl": renderJSONLines,
+	"csv":   renderCSV,
+}
+
+func renderTable(w io.Writer, resource resource, result interface{}) error {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(resource.columns)
+	table.SetAutoWrapText(false)
+	table.AppendBulk(resource.rows(result))
+	table.Render()
+	return nil
+}
+
+func renderJSON(w io.Writer, _ resource, result interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// Renders every model on its own line.
+func renderJSONLines(w io.Writer, _ resource, result interface{}) error {
+	encoder := json.NewEncoder(w)
+	items := reflect.ValueOf(result).Elem()
+	for index := 0; index < items.Len(); index++ {
+		if err := encoder.Encode(items.Index(index).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderCSV(w io.Writer, resource resource, result interface{}) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(resource.columns); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(resource.rows(result)); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// A flag that can be given multiple times, eg. -filter status=running -filter league_id=4158.
+type repeatedFlag []string
+
+func (f *repeatedFlag) String() string {
+	return strings.Join(*f, " ")
+}
+
+func (f *repeatedFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// Flags shared by all query commands.
+type queryFlags struct {
+	game     string
+	token    string
+	output   string
+	filters  repeatedFlag
+	ranges   repeatedFlag
+	searches repeatedFlag
+	sorts    repeatedFlag
+	page     int
+	pageSize int
+	all      bool
+}
+
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *queryFlags) {
+	flags := &queryFlags{}
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	set.SetOutput(stderr)
+	set.StringVar(&flags.game, "game", string(pandascore.CSGO), "game to query: csgo, dota2 or lol")
+	set.StringVar(&flags.token, "token", "", "PandaScore access token; defaults to $"+pandascore.AccessTokenEnvironmentVariable)
+	set.StringVar(&flags.output, "output", "table", "output format: table, json, jsonl or csv")
+	set.Var(&flags.filters, "filter", "filter as field=value[,value...]; can be repeated")
+	set.Var(&flags.ranges, "range", "range as field=lower,upper; can be repeated")
+	set.Var(&flags.searches, "search", "search as field=value; can be repeated")
+	set.Var(&flags.sorts, "sort", "field to sort by, prefixed with - for descending order; can be repeated")
+	set.IntVar(&flags.page, "page", 1, "page to fetch")
+	set.IntVar(&flags.pageSize, "page-size", 50, "number of results per page, at most 100")
+	set.BoolVar(&flags.all, "all", false, "fetch all pages instead of a single one")
+	return set, flags
+}
+
+// Apply the flags to the given request.
+func (f *queryFlags) apply(request *pandascore.Request) error {
+	for _, filter := range f.filters {
+		field, value, err := splitFlag("filter", filter)
+		if err != nil {
+			return err
+		}
+		request.Filter(field, value)
+	}
+	for _, search := range f.searches {
+		field, value, err := splitFlag("search", search)
+		if err != nil {
+			return err
+		}
+		request.Search(field, value)
+	}
+	for _, bounds := range f.ranges {
+		field, value, err := splitFlag("range", bounds)
+		if err != nil {
+			return err
+		}
+		lowerAndUpper := strings.SplitN(value, ",", 2)
+		if len(lowerAndUpper) != 2 {
+			return fmt.Errorf("invalid range %q, expected field=lower,upper", bounds)
+		}
+		request.Range(field, lowerAndUpper[0], lowerAndUpper[1])
+	}
+	for _, field := range f.sorts {
+		if strings.HasPrefix(field, "-") {
+			request.Sort(field[1:], pandascore.Descending)
+		} else {
+			request.Sort(field, pandascore.Ascending)
+		}
+	}
+
+	request.PageSize(f.pageSize)
+	if !f.all {
+		request.Page(f.page)
+	}
+	return nil
+}
+
+// Split a flag value of the form field=value.
+func splitFlag(name string, value string) (string, string, error) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 {
+		return "", "", fmt.Errorf("invalid %s %q, expected field=value", name, value)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Execute the query command for the given resource.
+func query(name string, resource resource, args []string, stdout io.Writer, stderr io.Writer, client *pandascore.Client) error {
+	set, flags := newFlagSet(name, stderr)
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+	if set.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(set.Args(), " "))
+	}
+
+	game := pandascore.Game(flags.game)
+	if !game.IsValid() {
+		return fmt.Errorf("invalid game %q", flags.game)
+	}
+	render, ok := renderers[flags.output]
+	if !ok {
+		return fmt.Errorf("invalid output format %q", flags.output)
+	}
+	if len(flags.token) > 0 {
+		client.AccessToken(flags.token)
+	}
+
+	request := client.Request(game, resource.path)
+	if err := flags.apply(request); err != nil {
+		return err
+	}
+
+	result := resource.list()
+	var err error
+	if flags.all {
+		_, err = request.GetAll(result)
+	} else {
+		_, err = request.Get(result)
+	}
+	if err != nil {
+		return err
+	}
+
+	return render(stdout, resource, result)
+}
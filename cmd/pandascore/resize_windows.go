@@ -0,0 +1,6 @@
+package main
+
+import "os"
+
+// Windows has no resize signal; the new size is picked up on the next refresh instead.
+func notifyResize(chan<- os.Signal) {}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// A resource that can be queried, with the columns it's rendered with in table and CSV output.
+type resource struct {
+	path    string
+	list    func() interface{}
+	columns []string
+	rows    func(result interface{}) [][]string
+}
+
+var resources = map[string]resource{
+	"leagues":          leagues,
+	"series":           series,
+	"matches upcoming": matches("matches/upcoming"),
+	"matches running":  matches("matches/running"),
+	"matches past":     matches("matches/past"),
+	"teams":            teams,
+	"players":          players,
+}
+
+var leagues = resource{
+	path:    "leagues",
+	list:    func() interface{} { return new([]pandascore.League) },
+	columns: []string{"ID", "Name", "Slug", "Modified"},
+	rows: func(result interface{}) [][]string {
+		var rows [][]string
+		for _, league := range *result.(*[]pandascore.League) {
+			rows = append(rows, []string{strconv.Itoa(league.ID), league.Name, league.Slug, formatTime(league.Modified)})
+		}
+		return rows
+	},
+}
+
+var series = resource{
+	path:    "series",
+	list:    func() interface{} { return new([]pandascore.Series) },
+	columns: []string{"ID", "Name", "League", "Begins at", "Ends at", "Modified"},
+	rows: func(result interface{}) [][]string {
+		var rows [][]string
+		for _, serie := range *result.(*[]pandascore.Series) {
+			rows = append(rows, []string{
+				strconv.Itoa(serie.ID),
+				serie.FullName,
+				serie.League.Name,
+				formatTime(serie.BeginsAt),
+				formatTime(serie.EndsAt),
+				formatTime(serie.Modified),
+			})
+		}
+		return rows
+	},
+}
+
+func matches(path string) resource {
+	return resource{
+		path:    path,
+		list:    func() interface{} { return new([]pandascore.Match) },
+		columns: []string{"ID", "Name", "Status", "Scheduled at", "League", "Series", "Score"},
+		rows: func(result interface{}) [][]string {
+			var rows [][]string
+			for _, match := range *result.(*[]pandascore.Match) {
+				rows = append(rows, []string{
+					strconv.Itoa(match.ID),
+					match.Name,
+					match.Status,
+					formatTime(match.ScheduledAt),
+					match.League.Name,
+					match.Series.FullName,
+					formatScore(match),
+				})
+			}
+			return rows
+		},
+	}
+}
+
+var teams = resource{
+	path:    "teams",
+	list:    func() interface{} { return new([]pandascore.Team) },
+	columns: []string{"ID", "Name", "Acronym", "Location", "Players"},
+	rows: func(result interface{}) [][]string {
+		var rows [][]string
+		for _, team := range *result.(*[]pandascore.Team) {
+			names := make([]string, len(team.Players))
+			for index, player := range team.Players {
+				names[index] = player.Name
+			}
+			rows = append(rows, []string{
+				strconv.Itoa(team.ID),
+				team.Name,
+				team.Acronym,
+				team.Location,
+				strings.Join(names, ", "),
+			})
+		}
+		return rows
+	},
+}
+
+var players = resource{
+	path:    "players",
+	list:    func() interface{} { return new([]pandascore.Player) },
+	columns: []string{"ID", "Name", "First name", "Last name", "Nationality", "Role", "Team"},
+	rows: func(result interface{}) [][]string {
+		var rows [][]string
+		for _, player := range *result.(*[]pandascore.Player) {
+			rows = append(rows, []string{
+				strconv.Itoa(player.ID),
+				player.Name,
+				player.FirstName,
+				player.LastName,
+				player.Nationality,
+				player.Role,
+				player.CurrentTeam.Name,
+			})
+		}
+		return rows
+	},
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Formats the score of a match with two opponents as eg. 2-1; empty for matches without opponents.
+func formatScore(match pandascore.Match) string {
+	scores := make([]string, len(match.Opponents))
+	for index, opponent := range match.Opponents {
+		scores[index] = strconv.Itoa(match.ScoreOf(opponent.Opponent.ID))
+	}
+	return strings.Join(scores, "-")
+}
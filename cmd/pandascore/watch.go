@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/tmbrggmn/pandascore-go"
+	"golang.org/x/term"
+)
+
+// Terminal escape sequences used to redraw the scoreboard in place.
+const (
+	cursorHome = "\x1b[H"
+	clearLine  = "\x1b[K"
+	clearBelow = "\x1b[J"
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+)
+
+const (
+	// Size assumed when the output isn't a terminal
+	defaultWidth  = 80
+	defaultHeight = 24
+
+	// Team names are cut off at this width to keep the scores aligned
+	maxNameWidth = 24
+
+	// Default time between two refreshes of the scoreboard
+	defaultInterval = 15 * time.Second
+)
+
+// Scoreboard of all running matches of a number of games.
+type scoreboard struct {
+	client  *pandascore.Client
+	games   []pandascore.Game
+	league  string
+	team    string
+	matches []pandascore.Match
+	updated time.Time
+	err     error
+}
+
+// Fetch the running matches of all games. If fetching fails, the previous matches are kept and the error is shown.
+func (s *scoreboard) update(now time.Time) {
+	var matches []pandascore.Match
+	for _, game := range s.games {
+		running, err := s.client.GetAllRunningMatches(game)
+		if err != nil {
+			s.err = err
+			return
+		}
+		matches = append(matches, running...)
+	}
+
+	s.matches = filterMatches(matches, s.league, s.team)
+	s.updated = now
+	s.err = nil
+}
+
+// Returns the matches whose league contains the given league name and that have an opponent whose name or acronym
+// contains the given team name. Empty names match everything; matching is case-insensitive.
+func filterMatches(matches []pandascore.Match, league string, team string) []pandascore.Match {
+	league, team = strings.ToLower(league), strings.ToLower(team)
+
+	var filtered []pandascore.Match
+	seen := make(map[int]bool)
+	for _, match := range matches {
+		if seen[match.ID] || !strings.Contains(strings.ToLower(match.League.Name), league) {
+			continue
+		}
+		found := len(team) == 0
+		for _, opponent := range match.Opponents {
+			if strings.Contains(strings.ToLower(opponent.Opponent.Name), team) ||
+				strings.Contains(strings.ToLower(opponent.Opponent.Acronym), team) {
+				found = true
+			}
+		}
+		if found {
+			seen[match.ID] = true
+			filtered = append(filtered, match)
+		}
+	}
+	return filtered
+}
+
+// Returns the lines of the scoreboard, each cut off at the given width.
+func (s *scoreboard) lines(width int) []string {
+	games := make([]string, len(s.games))
+	for index, game := range s.games {
+		games[index] = string(game)
+	}
+	status := fmt.Sprintf("Running matches: %s", strings.Join(games, ", "))
+	if !s.updated.IsZero() {
+		status += " · updated " + s.updated.Format("15:04:05")
+	}
+	lines := []string{status}
+	if s.err != nil {
+		lines = append(lines, "Failed to refresh: "+s.err.Error())
+	}
+	lines = append(lines, "")
+
+	if len(s.matches) == 0 && !s.updated.IsZero() {
+		lines = append(lines, "No running matches")
+	}
+	for _, match := range s.matches {
+		lines = append(lines, matchLines(match)...)
+		lines = append(lines, "")
+	}
+
+	for index, line := range lines {
+		lines[index] = truncate(line, width)
+	}
+	return lines
+}
+
+// Returns the lines of a single match: a header followed by a line per opponent with its series score and the result
+// of every map (W for won, L for lost, * for running and . for not played yet).
+func matchLines(match pandascore.Match) []string {
+	header := match.League.Name
+	if len(match.Series.FullName) > 0 {
+		header += " · " + match.Series.FullName
+	}
+	if current, ok := match.CurrentGame(); ok {
+		header += fmt.Sprintf(" · map %d of %d", current.Position, match.NumberOfGames)
+	} else if match.NumberOfGames > 0 {
+		header += fmt.Sprintf(" · best of %d", match.NumberOfGames)
+	}
+	lines := []string{header}
+
+	nameWidth := 0
+	for _, opponent := range match.Opponents {
+		if length := len([]rune(opponent.Opponent.Name)); length > nameWidth {
+			nameWidth = length
+		}
+	}
+	if nameWidth > maxNameWidth {
+		nameWidth = maxNameWidth
+	}
+
+	for _, opponent := range match.Opponents {
+		maps := make([]string, len(match.Games))
+		for index, game := range match.Games {
+			switch {
+			case game.Finished && game.Winner.ID == opponent.Opponent.ID:
+				maps[index] = "W"
+			case game.Finished:
+				maps[index] = "L"
+			case game.IsRunning():
+				maps[index] = "*"
+			default:
+				maps[index] = "."
+			}
+		}
+		name := truncate(opponent.Opponent.Name, nameWidth)
+		lines = append(lines, fmt.Sprintf("  %-*s %2d  %s", nameWidth, name, match.ScoreOf(opponent.Opponent.ID),
+			strings.Join(maps, " ")))
+	}
+	return lines
+}
+
+// Cut off the given line at the given width in runes.
+func truncate(line string, width int) string {
+	runes := []rune(line)
+	if width <= 0 || len(runes) <= width {
+		return line
+	}
+	return string(runes[:width])
+}
+
+// Redraw the scoreboard in place on a terminal with the given size.
+func (s *scoreboard) render(w io.Writer, width int, height int) error {
+	lines := s.lines(width)
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+
+	var builder strings.Builder
+	builder.WriteString(cursorHome)
+	for index, line := range lines {
+		builder.WriteString(line)
+		builder.WriteString(clearLine)
+		if index < len(lines)-1 {
+			builder.WriteString("\r\n")
+		}
+	}
+	builder.WriteString(clearBelow)
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// Returns the size of the terminal the given writer writes to, or a default size if it isn't a terminal.
+func terminalSize(w io.Writer) (int, int) {
+	if file, ok := w.(*os.File); ok {
+		if width, height, err := term.GetSize(int(file.Fd())); err == nil {
+			return width, height
+		}
+	}
+	return defaultWidth, defaultHeight
+}
+
+// Execute the watch command, which shows a scoreboard of all running matches until it's interrupted.
+func watch(args []string, stdout io.Writer, stderr io.Writer, client *pandascore.Client) error {
+	set := flag.NewFlagSet("watch", flag.ContinueOnError)
+	set.SetOutput(stderr)
+	games := set.String("games", string(pandascore.CSGO), "comma separated games to watch: csgo, dota2 and/or lol")
+	league := set.String("league", "", "only show matches of leagues whose name contains this value")
+	team := set.String("team", "", "only show matches of teams whose name or acronym contains this value")
+	interval := set.Duration("interval", defaultInterval, "time between two refreshes")
+	token := set.String("token", "", "PandaScore access token; defaults to $"+pandascore.AccessTokenEnvironmentVariable)
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("invalid interval %s", *interval)
+	}
+	if len(*token) > 0 {
+		client.AccessToken(*token)
+	}
+
+	board := &scoreboard{client: client, league: *league, team: *team}
+	for _, name := range strings.Split(*games, ",") {
+		game := pandascore.Game(strings.TrimSpace(name))
+		if !game.IsValid() {
+			return fmt.Errorf("invalid game %q", name)
+		}
+		board.games = append(board.games, game)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt)
+	defer signal.Stop(interrupted)
+	go func() {
+		select {
+		case <-interrupted:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	resized := make(chan os.Signal, 1)
+	notifyResize(resized)
+	defer signal.Stop(resized)
+
+	fmt.Fprint(stdout, hideCursor)
+	defer fmt.Fprint(stdout, showCursor+"\r\n")
+	return board.run(ctx, stdout, *interval, resized)
+}
+
+// Refresh the scoreboard at the given interval and redraw it after every refresh and whenever the terminal is resized,
+// until the given context is done.
+func (s *scoreboard) run(ctx context.Context, w io.Writer, interval time.Duration, resized <-chan os.Signal) error {
+	refresh := time.NewTimer(0)
+	defer refresh.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-resized:
+		case now := <-refresh.C:
+			s.update(now)
+			refresh.Reset(interval)
+		}
+
+		width, height := terminalSize(w)
+		if err := s.render(w, width, height); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func readRunningMatches(t *testing.T) []pandascore.Match {
+	content, err := ioutil.ReadFile("../../testdata/csgo-matches-running.json")
+	assert.Nil(t, err)
+
+	var matches []pandascore.Match
+	assert.Nil(t, json.Unmarshal(content, &matches))
+	return matches
+}
+
+func TestFilterMatches(t *testing.T) {
+	matches := readRunningMatches(t)
+
+	assert.Len(t, filterMatches(matches, "", ""), 3, "Expected duplicate matches to be removed")
+	assert.Len(t, filterMatches(matches, "esl", ""), 2)
+	assert.Len(t, filterMatches(matches, "", "faze"), 1)
+	assert.Len(t, filterMatches(matches, "loot.bet", "faze"), 0)
+}
+
+func TestMatchLines(t *testing.T) {
+	lines := matchLines(readRunningMatches(t)[0])
+
+	assert.Equal(t, []string{
+		"ESL · One: Road to Rio - Europe 2020 · map 2 of 3",
+		"  FaZe   1  W * .",
+		"  North  0  L * .",
+	}, lines)
+}
+
+func TestScoreboard_render(t *testing.T) {
+	board := &scoreboard{
+		games:   []pandascore.Game{pandascore.CSGO, pandascore.LoL},
+		matches: readRunningMatches(t)[:1],
+		updated: time.Date(2020, time.April, 23, 13, 0, 0, 0, time.UTC),
+	}
+
+	output := new(bytes.Buffer)
+	assert.Nil(t, board.render(output, 20, 4))
+
+	assert.True(t, strings.HasPrefix(output.String(), cursorHome))
+	assert.True(t, strings.HasSuffix(output.String(), clearBelow))
+	lines := strings.Split(strings.TrimSuffix(strings.TrimPrefix(output.String(), cursorHome), clearBelow), "\r\n")
+	assert.Len(t, lines, 4, "Expected lines not to exceed the terminal height")
+	for _, line := range lines {
+		assert.LessOrEqual(t, len([]rune(strings.TrimSuffix(line, clearLine))), 20, "Expected lines not to exceed the terminal width")
+	}
+	assert.Equal(t, "Running matches: csg"+clearLine, lines[0])
+}
+
+func TestScoreboard_update(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Reply(http.StatusOK).
+		File("../../testdata/csgo-matches-running.json")
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Reply(http.StatusInternalServerError).
+		JSON(map[string]string{"error": "Internal server error"})
+
+	board := &scoreboard{client: pandascore.New(), games: []pandascore.Game{pandascore.CSGO}, team: "north"}
+	now := time.Now()
+	board.update(now)
+
+	assert.Nil(t, board.err)
+	assert.Len(t, board.matches, 1)
+	assert.Equal(t, now, board.updated)
+
+	board.update(now.Add(time.Minute))
+	assert.NotNil(t, board.err)
+	assert.Len(t, board.matches, 1, "Expected the previous matches to be kept when refreshing fails")
+	assert.Equal(t, now, board.updated)
+	assert.Contains(t, strings.Join(board.lines(80), "\n"), "Failed to refresh")
+}
+
+func TestScoreboard_run(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Persist().
+		Reply(http.StatusOK).
+		File("../../testdata/csgo-matches-running.json")
+
+	board := &scoreboard{client: pandascore.New(), games: []pandascore.Game{pandascore.CSGO}}
+	output := &notifyingBuffer{written: make(chan struct{}, 10)}
+	resized := make(chan os.Signal, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error)
+	go func() { done <- board.run(ctx, output, time.Hour, resized) }()
+
+	<-output.written
+	resized <- os.Interrupt
+	<-output.written
+	cancel()
+
+	assert.Nil(t, <-done)
+	assert.Equal(t, 2, strings.Count(output.String(), cursorHome), "Expected a redraw after a refresh and a resize")
+}
+
+func TestWatch_invalidArguments(t *testing.T) {
+	_, err := execute("watch", "-games", "csgo,chess")
+	assert.EqualError(t, err, `invalid game "chess"`)
+
+	_, err = execute("watch", "-interval", "0s")
+	assert.EqualError(t, err, "invalid interval 0s")
+}
+
+// Buffer that signals every write, so tests can wait for redraws.
+type notifyingBuffer struct {
+	bytes.Buffer
+	written chan struct{}
+}
+
+func (b *notifyingBuffer) Write(p []byte) (int, error) {
+	n, err := b.Buffer.Write(p)
+	b.written <- struct{}{}
+	return n, err
+}
+
+func (b *notifyingBuffer) WriteString(s string) (int, error) {
+	return b.Write([]byte(s))
+}
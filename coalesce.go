@@ -0,0 +1,79 @@
+package pandascore
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Requests that are in flight, keyed by request URL and priority, so identical requests made in the meantime can share
+// their result instead of being sent to the PandaScore API as well.
+//
+// Only requests of the same priority are shared, so a realtime request never ends up waiting behind background ones in
+// the queue of the scheduler. A shared request is counted once in the usage of the client, for the tag of the caller
+// that sent it; every caller counts the page it gets for its own tag.
+type inFlight struct {
+	mutex sync.Mutex
+	calls map[callKey]*call
+}
+
+type callKey struct {
+	key      string
+	priority Priority
+}
+
+// A request that's in flight along with its result once it's done.
+type call struct {
+	done     chan struct{}
+	body     []byte
+	response Response
+	err      error
+}
+
+// Executes the given function for the given key, unless a call with the same key and the priority of the given context
+// is already in flight, in which case its result is returned instead. Every caller gets its own copy of the body.
+//
+// When the call in flight fails because its context was cancelled, callers whose own context is still fine execute
+// the function themselves; they shouldn't fail because another caller gave up.
+func (f *inFlight) do(ctx context.Context, key string, fn func() ([]byte, Response, error)) ([]byte, Response, error) {
+	id := callKey{key: key, priority: PriorityFromContext(ctx)}
+	f.mutex.Lock()
+	if f.calls == nil {
+		f.calls = make(map[callKey]*call)
+	}
+	if existing, ok := f.calls[id]; ok {
+		f.mutex.Unlock()
+
+		select {
+		case <-existing.done:
+		case <-ctx.Done():
+			return nil, Response{}, ctx.Err()
+		}
+		if isContextError(existing.err) && ctx.Err() == nil {
+			return f.do(ctx, key, fn)
+		}
+		if existing.err != nil {
+			return nil, Response{}, existing.err
+		}
+		return append([]byte(nil), existing.body...), existing.response, nil
+	}
+	current := &call{done: make(chan struct{})}
+	f.calls[id] = current
+	f.mutex.Unlock()
+
+	current.body, current.response, current.err = fn()
+
+	f.mutex.Lock()
+	delete(f.calls, id)
+	f.mutex.Unlock()
+	close(current.done)
+
+	if current.err != nil {
+		return nil, Response{}, current.err
+	}
+	return append([]byte(nil), current.body...), current.response, nil
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,152 @@
+package pandascore
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Starts a server that serves the running CS:GO matches once release is closed and counts the requests it receives.
+func newBlockingServer(t *testing.T, release chan struct{}, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		content, err := ioutil.ReadFile("testdata/csgo-matches-running.json")
+		assert.Nil(t, err)
+		w.Header().Set("X-Page", "1")
+		w.Header().Set("X-Per-Page", "100")
+		w.Header().Set("X-Total", "4")
+		_, _ = w.Write(content)
+	}))
+}
+
+// Cache that counts its lookups, so tests know when a request is about to join the identical one in flight.
+type lookupCountingCache struct {
+	Cache
+	lookups int32
+}
+
+func (c *lookupCountingCache) Get(key string) (CacheEntry, bool) {
+	atomic.AddInt32(&c.lookups, 1)
+	return c.Cache.Get(key)
+}
+
+func TestRequest_Get_coalescing(t *testing.T) {
+	release := make(chan struct{})
+	var requests int32
+	server := newBlockingServer(t, release, &requests)
+	defer server.Close()
+
+	cache := &lookupCountingCache{Cache: NewMemoryCache(10)}
+	client := New().BaseURL(server.URL).Cache(cache, time.Minute)
+	results := make([][]Match, 5)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			matches, err := client.GetAllRunningMatches(CSGO)
+			assert.Nil(t, err)
+			results[i] = matches
+		}(i)
+	}
+
+	// Only respond once all other requests are about to wait for the first one
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&cache.lookups) == 5 && atomic.LoadInt32(&requests) == 1
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	for _, matches := range results {
+		assert.Len(t, matches, 4)
+	}
+	results[0][0].Name = "changed"
+	assert.NotEqual(t, "changed", results[1][0].Name, "Expected every caller to get its own copy")
+}
+
+func TestRequest_Get_coalescingCancelled(t *testing.T) {
+	release := make(chan struct{})
+	var requests int32
+	server := newBlockingServer(t, release, &requests)
+	defer server.Close()
+
+	cache := &lookupCountingCache{Cache: NewMemoryCache(10)}
+	client := New().BaseURL(server.URL).Cache(cache, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+	go func() {
+		_, err := client.Request(CSGO, "matches/running").Context(ctx).Get(new([]Match))
+		cancelled <- err
+	}()
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 1 }, time.Second, time.Millisecond)
+	done := make(chan error)
+	go func() {
+		matches := new([]Match)
+		_, err := client.Request(CSGO, "matches/running").Get(matches)
+		assert.Len(t, *matches, 4)
+		done <- err
+	}()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&cache.lookups) == 2 }, time.Second, time.Millisecond)
+
+	cancel()
+	assert.True(t, errors.Is(<-cancelled, context.Canceled))
+	close(release)
+	assert.Nil(t, <-done, "Expected the waiting request to be sent again instead of failing with the cancelled one")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestRequest_Get_coalescingPriority(t *testing.T) {
+	release := make(chan struct{})
+	var requests int32
+	server := newBlockingServer(t, release, &requests)
+	defer server.Close()
+
+	client := New().BaseURL(server.URL)
+	var wg sync.WaitGroup
+	get := func(priority Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Request(CSGO, "matches/running").Priority(priority).Get(new([]Match))
+			assert.Nil(t, err)
+		}()
+	}
+
+	get(PriorityBackground)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 1 }, time.Second, time.Millisecond)
+	get(PriorityRealtime)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 2 }, time.Second, time.Millisecond,
+		"Expected the realtime request not to wait for the background one")
+	close(release)
+	wg.Wait()
+}
+
+func TestRequest_Get_coalescingErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"Token is invalid"}`))
+	}))
+	defer server.Close()
+
+	var inFlight inFlight
+	_, _, err := inFlight.do(context.Background(), "key", func() ([]byte, Response, error) {
+		return New().BaseURL(server.URL).Request(CSGO, "leagues").fetch()
+	})
+	assert.EqualError(t, err, "PandaScore error: Token is invalid")
+	assert.Empty(t, inFlight.calls, "Expected the call to be removed once it's done")
+}
@@ -0,0 +1,304 @@
+package pandascore
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// A field is present in the PandaScore response but not in the struct it was decoded in
+	UnexpectedField DriftKind = "unexpected"
+
+	// A field is present in the struct but not in the PandaScore response
+	MissingField DriftKind = "missing"
+
+	// A field is present in both but the JSON type doesn't match the type of the struct field
+	TypeChanged DriftKind = "type_changed"
+
+	// Struct tag that marks a field PandaScore only includes in some responses, eg. `pandascore:"optional"`, so it's
+	// never reported as missing
+	optionalTag = "pandascore"
+)
+
+// DriftKind describes how a PandaScore response deviates from the struct it was decoded in.
+type DriftKind string
+
+// SchemaDrift represents a single field in a PandaScore response that doesn't match the struct it was decoded in.
+//
+// Path is the location of the field in the response, where array elements are denoted with [] (eg. [].league.slug).
+// Expected and Actual contain the JSON types (string, number, bool, array or object) of the struct field and the value
+// in the response, as far as they are known.
+type SchemaDrift struct {
+	Kind     DriftKind
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// SchemaDriftReport groups all schema drift detected in the response of a single endpoint (eg. csgo/matches/running).
+type SchemaDriftReport struct {
+	Endpoint string
+	Drift    []SchemaDrift
+}
+
+// Enables schema drift detection on this client. Every response is compared against the struct it's decoded in and
+// any unexpected, missing or type-changed fields are reported on the Response and passed to the given handler, which
+// may be nil. Drift never causes a request to fail; fields with a changed type are simply left empty.
+//
+// Fields tagged with `pandascore:"optional"` are never reported as missing, since PandaScore only includes them in
+// some responses.
+func (c *Client) DetectSchemaDrift(handler func(SchemaDriftReport)) *Client {
+	c.schemaDriftDetection = true
+	c.schemaDriftHandler = handler
+	return c
+}
+
+// Compares the given response body against the type of value and stores any drift on the response. Only values that
+// are (slices of) structs are compared, since there's nothing to drift from for eg. maps. The handler is only called if
+// drift was actually detected.
+func (c *Client) reportSchemaDrift(endpoint string, body []byte, value interface{}, response *Response) {
+	if !c.schemaDriftDetection || value == nil || !isStructOrSliceOfStructs(reflect.TypeOf(value)) {
+		return
+	}
+
+	drift := detectSchemaDrift(body, reflect.TypeOf(value))
+	if len(drift) == 0 {
+		return
+	}
+
+	response.SchemaDrift = drift
+	if c.schemaDriftHandler != nil {
+		c.schemaDriftHandler(SchemaDriftReport{Endpoint: endpoint, Drift: drift})
+	}
+}
+
+func isStructOrSliceOfStructs(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// Returns all schema drift between the given JSON body and type, sorted by path. Invalid JSON yields no drift.
+func detectSchemaDrift(body []byte, t reflect.Type) []SchemaDrift {
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil
+	}
+
+	detector := &driftDetector{seen: make(map[string]bool)}
+	detector.compare("", raw, t)
+
+	sort.Slice(detector.drift, func(i, j int) bool {
+		if detector.drift[i].Path == detector.drift[j].Path {
+			return detector.drift[i].Kind < detector.drift[j].Kind
+		}
+		return detector.drift[i].Path < detector.drift[j].Path
+	})
+	return detector.drift
+}
+
+// Keeps track of drift while walking a decoded JSON document. Because every element of an array is compared against
+// the same type, the same drift is only recorded once.
+type driftDetector struct {
+	drift []SchemaDrift
+	seen  map[string]bool
+}
+
+func (d *driftDetector) add(kind DriftKind, path string, expected string, actual string) {
+	key := string(kind) + ":" + path
+	if d.seen[key] {
+		return
+	}
+	d.seen[key] = true
+	d.drift = append(d.drift, SchemaDrift{Kind: kind, Path: path, Expected: expected, Actual: actual})
+}
+
+func (d *driftDetector) compare(path string, raw interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	expected := expectedJSONType(t)
+	actual := jsonType(raw)
+	if expected == "" || actual == "null" {
+		return
+	}
+	if expected != actual {
+		d.add(TypeChanged, path, expected, actual)
+		return
+	}
+
+	switch value := raw.(type) {
+	case []interface{}:
+		for _, element := range value {
+			d.compare(path+"[]", element, t.Elem())
+		}
+	case map[string]interface{}:
+		if t.Kind() == reflect.Map {
+			for key, element := range value {
+				d.compare(joinPath(path, key), element, t.Elem())
+			}
+		} else {
+			d.compareStruct(path, value, t)
+		}
+	}
+}
+
+func (d *driftDetector) compareStruct(path string, object map[string]interface{}, t reflect.Type) {
+	fields := jsonFields(t)
+
+	for key, element := range object {
+		field, ok := fields[strings.ToLower(key)]
+		if !ok {
+			d.add(UnexpectedField, joinPath(path, key), "", jsonType(element))
+			continue
+		}
+		d.compare(joinPath(path, field.name), element, field.typ)
+	}
+
+	for _, field := range fields {
+		if field.optional {
+			continue
+		}
+		if _, ok := findKey(object, field.name); !ok {
+			d.add(MissingField, joinPath(path, field.name), expectedJSONType(field.typ), "")
+		}
+	}
+}
+
+type jsonField struct {
+	name     string
+	typ      reflect.Type
+	optional bool
+}
+
+// Returns the exported fields of the given struct type keyed by their lowercase JSON name, which is how
+// encoding/json matches keys to fields as well. The fields of embedded structs without a JSON name are included, unless
+// the struct itself has a field with the same name.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := make(map[string]jsonField)
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := tag
+		if index := strings.Index(tag, ","); index >= 0 {
+			name = tag[:index]
+		}
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				embedded = append(embedded, fieldType)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[strings.ToLower(name)] = jsonField{
+			name:     name,
+			typ:      field.Type,
+			optional: field.Tag.Get(optionalTag) == "optional",
+		}
+	}
+
+	for _, embeddedType := range embedded {
+		for key, field := range jsonFields(embeddedType) {
+			if _, ok := fields[key]; !ok {
+				fields[key] = field
+			}
+		}
+	}
+	return fields
+}
+
+func findKey(object map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := object[name]; ok {
+		return value, true
+	}
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// Returns the JSON type that encoding/json expects for the given Go type or an empty string if it can't be determined,
+// for example for interfaces or types with their own UnmarshalJSON implementation.
+func expectedJSONType(t reflect.Type) string {
+	if t == timeType {
+		return "string"
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return ""
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+// Returns the JSON type of a value decoded by encoding/json into an interface{}.
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,166 @@
+package pandascore
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type driftTestLeague struct {
+	ID       int       `json:"id"`
+	Name     string    `json:"name"`
+	Modified time.Time `json:"modified_at"`
+	URL      string    `json:"url"`
+	Slug     int       `json:"slug"`
+	Tier     string    `json:"tier"`
+	Region   string    `json:"region" pandascore:"optional"`
+	Series   []struct {
+		ID   int    `json:"id"`
+		Year string `json:"year"`
+	} `json:"series"`
+	Videogame map[string]interface{} `json:"videogame"`
+}
+
+func Test_detectSchemaDrift(t *testing.T) {
+	body, _ := ioutil.ReadFile("testdata/csgo-leagues-esl.json")
+
+	result := detectSchemaDrift(body, reflect.TypeOf(new([]driftTestLeague)))
+
+	assert.Len(t, result, 15)
+	assert.Contains(t, result, SchemaDrift{Kind: UnexpectedField, Path: "[].image_url", Actual: "string"})
+	assert.Contains(t, result, SchemaDrift{Kind: UnexpectedField, Path: "[].series[].full_name", Actual: "string"})
+	assert.Contains(t, result, SchemaDrift{Kind: MissingField, Path: "[].tier", Expected: "string"})
+	assert.Contains(t, result, SchemaDrift{Kind: TypeChanged, Path: "[].slug", Expected: "number", Actual: "string"})
+	assert.Contains(t, result, SchemaDrift{Kind: TypeChanged, Path: "[].series[].year", Expected: "string", Actual: "number"})
+	assert.NotContains(t, result, SchemaDrift{Kind: MissingField, Path: "[].region", Expected: "string"})
+}
+
+func Test_detectSchemaDrift_optional(t *testing.T) {
+	type value struct {
+		Name     string `json:"name"`
+		Region   string `json:"region,omitempty"`
+		Streams  []int  `json:"streams" pandascore:"optional"`
+		Nickname string `json:"nickname" pandascore:"required"`
+	}
+
+	result := detectSchemaDrift([]byte(`{"name":"ESL"}`), reflect.TypeOf(new(value)))
+
+	assert.Equal(t, []SchemaDrift{
+		{Kind: MissingField, Path: "nickname", Expected: "string"},
+		{Kind: MissingField, Path: "region", Expected: "string"},
+	}, result, "Expected only fields marked as optional to be left out")
+}
+
+type driftTestBase struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func Test_detectSchemaDrift_embedded(t *testing.T) {
+	type value struct {
+		driftTestBase
+		*League `json:"league"`
+		Name    int `json:"name"`
+	}
+
+	result := detectSchemaDrift([]byte(`{"id":1,"name":2,"league":null}`), reflect.TypeOf(new(value)))
+
+	assert.Empty(t, result, "Expected the fields of embedded structs to be compared, unless they're shadowed")
+}
+
+func Test_detectSchemaDrift_withoutDrift(t *testing.T) {
+	body, _ := ioutil.ReadFile("testdata/error-missing-access-token.json")
+
+	result := detectSchemaDrift(body, reflect.TypeOf(new(PandaScoreError)))
+
+	assert.Empty(t, result)
+}
+
+func Test_detectSchemaDrift_invalidJSON(t *testing.T) {
+	result := detectSchemaDrift([]byte("not json"), reflect.TypeOf(new([]League)))
+
+	assert.Empty(t, result)
+}
+
+func TestClient_DetectSchemaDrift(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json")
+
+	var reports []SchemaDriftReport
+	leagues := new([]driftTestLeague)
+	response, err := New().
+		DetectSchemaDrift(func(report SchemaDriftReport) { reports = append(reports, report) }).
+		Request(CSGO, "leagues").
+		Get(leagues)
+
+	assert.Nil(t, err, "Expected type changes to be reported instead of failing the request")
+	assert.Len(t, *leagues, 1)
+	assert.Equal(t, "ESL", (*leagues)[0].Name)
+	assert.Len(t, response.SchemaDrift, 15)
+	assert.Len(t, reports, 1)
+	assert.Equal(t, "csgo/leagues", reports[0].Endpoint)
+	assert.Equal(t, response.SchemaDrift, reports[0].Drift)
+}
+
+func TestClient_DetectSchemaDrift_GetAll(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json")
+
+	var reports []SchemaDriftReport
+	leagues := new([]driftTestLeague)
+	response, err := New().
+		DetectSchemaDrift(func(report SchemaDriftReport) { reports = append(reports, report) }).
+		Request(CSGO, "leagues").
+		GetAll(leagues)
+
+	assert.Nil(t, err)
+	assert.Len(t, *leagues, 1)
+	assert.Len(t, response.SchemaDrift, 15)
+	assert.Len(t, reports, 1, "Expected drift to be reported once for the merged result")
+}
+
+func TestClient_DetectSchemaDrift_map(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json")
+
+	reported := false
+	response, err := New().
+		DetectSchemaDrift(func(SchemaDriftReport) { reported = true }).
+		Request(CSGO, "leagues").
+		Get(new([]map[string]interface{}))
+
+	assert.Nil(t, err)
+	assert.Empty(t, response.SchemaDrift)
+	assert.False(t, reported, "Expected maps not to be checked for drift")
+}
+
+func TestClient_DetectSchemaDrift_disabled(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json")
+
+	response, err := New().Request(CSGO, "leagues").Get(new([]driftTestLeague))
+
+	assert.NotNil(t, err, "Expected type changes to fail the request without schema drift detection")
+	assert.Empty(t, response.SchemaDrift)
+}
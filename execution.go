@@ -2,7 +2,9 @@ package pandascore
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
@@ -15,29 +17,142 @@ import (
 //
 // In case there was an error executing the request, an empty response struct is returned.
 func (r *Request) Get(value interface{}) (Response, error) {
-	if !r.game.IsValid() {
-		return Response{}, fmt.Errorf("unknown game '%s'", r.game)
+	body, response, err := r.get(value)
+	if err != nil {
+		return Response{}, err
+	}
+
+	r.client.reportSchemaDrift(r.endpoint(), body, value, &response)
+	return response, nil
+}
+
+// Fetch the first page of results and unmarshal it like Get, without checking the response for schema drift. Returns
+// the response body along with the response.
+func (r *Request) get(value interface{}) ([]byte, Response, error) {
+	body, response, err := r.fetch()
+	if err != nil {
+		log.Printf("PandaScore request failed with error: %s", err)
+		return nil, Response{}, err
+	}
+
+	err = json.Unmarshal(body, value)
+	if err != nil && !r.client.ignoresUnmarshalError(err) {
+		log.Printf("failed to unmarshal PandaScore response: %s", err)
+		return nil, Response{}, err
+	}
+	return body, response, nil
+}
+
+// Execute a single request against the PandaScore API like Get, but return the raw response body instead of
+// unmarshalling it, eg. to forward it as is.
+func (r *Request) GetRaw() ([]byte, Response, error) {
+	return r.fetch()
+}
+
+// Fetch the response body of the request like load and count it in the usage of the client.
+func (r *Request) fetch() ([]byte, Response, error) {
+	body, response, err := r.load()
+	r.client.usage.page(r.tag, r.endpoint(), err)
+	return body, response, err
+}
+
+// Load the response body of the request, from the cache of the client if it has a fresh copy or from the PandaScore
+// API otherwise. Identical requests that are in flight at the same time are only sent once. While the circuit of the
+// request is open, the cached copy is returned regardless of its age, or up to the maximum age of ServeStale if set.
+// See ServeStale for serving cached copies when the API fails.
+func (r *Request) load() ([]byte, Response, error) {
+	if !r.allGames && !r.game.IsValid() {
+		return nil, Response{}, fmt.Errorf("unknown game '%s'", r.game)
 	}
 
 	request, err := buildRequest(r)
 	if err != nil {
 		log.Printf("unable to build new PandaScore request: %s", err)
-		return Response{}, err
+		return nil, Response{}, err
+	}
+
+	key := request.URL.String()
+	if body, response, ok := r.client.cached(key); ok {
+		return body, response, nil
+	}
+
+	if body, response, ok := r.client.refreshing(key); ok {
+		return body, response, nil
 	}
 
-	httpResponse, err := r.client.httpClient.Do(request)
+	group := r.group()
+	body, response, err := r.client.inFlight.do(request.Context(), key, func() ([]byte, Response, error) {
+		return r.client.send(group, request)
+	})
 	if err != nil {
-		log.Printf("PandaScore request failed with error: %s", err)
-		return Response{}, err
+		if body, response, ok := r.client.fallback(key, group, request, err); ok {
+			return body, response, nil
+		}
+		// With ServeStale, the fallback has already served the cached copy if it's not too old
+		if errors.Is(err, ErrCircuitOpen) && !r.client.serveStale {
+			if body, response, ok := r.client.cachedStale(key, 0); ok {
+				return body, response, nil
+			}
+		}
+	}
+	return body, response, err
+}
+
+// Send the given request to the PandaScore API and store the response in the cache. The request waits for the rate
+// limiter or scheduler of the client, if it has one, and is only sent if the circuit of its endpoint group isn't open.
+func (c *Client) send(group string, request *http.Request) (body []byte, response Response, err error) {
+	// Fail fast while the circuit is open, without using up the quota of the rate limiter or scheduler
+	if c.circuitBreaker != nil {
+		if err := c.circuitBreaker.check(group); err != nil {
+			return nil, Response{}, err
+		}
 	}
 
-	err = unmarshallResponseBody(httpResponse, value)
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(request.Context()); err != nil {
+			return nil, Response{}, err
+		}
+	}
+	if c.scheduler != nil {
+		if err := c.scheduler.Wait(request.Context(), PriorityFromContext(request.Context())); err != nil {
+			if c.rateLimiter != nil {
+				c.rateLimiter.refund()
+			}
+			return nil, Response{}, err
+		}
+	}
+
+	// Only claim the trial request of a half-open circuit once the request is about to be sent, so it isn't held up
+	// while the request is queued, and running out of time in the queue isn't taken for a failure of the API. If the
+	// circuit opened or another trial request got ahead in the meantime, the quota is given back.
+	if c.circuitBreaker != nil {
+		if err := c.circuitBreaker.allow(group); err != nil {
+			if c.rateLimiter != nil {
+				c.rateLimiter.refund()
+			}
+			if c.scheduler != nil {
+				c.scheduler.refund()
+			}
+			return nil, Response{}, err
+		}
+		defer func() { c.circuitBreaker.record(group, err) }()
+	}
+
+	httpResponse, err := c.httpClient.Do(request)
 	if err != nil {
-		log.Printf("failed to unmarshal PandaScore response: %s", err)
-		return Response{}, err
+		c.usage.request(tagFromContext(request.Context()), c.endpointOf(request), nil, 0)
+		return nil, Response{}, err
+	}
+
+	body, err = readResponseBody(httpResponse)
+	c.usage.request(tagFromContext(request.Context()), c.endpointOf(request), httpResponse, len(body))
+	if err != nil {
+		return nil, Response{}, err
 	}
 
-	return constructResponse(httpResponse), nil
+	response = constructResponse(httpResponse)
+	c.store(request.URL.String(), body, response)
+	return body, response, nil
 }
 
 // Execute multiple requests against the PandaScore API to fetch all results from all pages and marshal the response
@@ -47,7 +162,7 @@ func (r *Request) Get(value interface{}) (Response, error) {
 func (r *Request) GetAll(value interface{}) (Response, error) {
 	// Get the first page of results and store them into a generic map so we can merge all results into that
 	jsonResponseAsMap := new([]map[string]interface{})
-	response, err := r.Get(jsonResponseAsMap)
+	_, response, err := r.get(jsonResponseAsMap)
 	if err != nil {
 		return Response{}, err
 	}
@@ -58,10 +173,12 @@ func (r *Request) GetAll(value interface{}) (Response, error) {
 			nextJsonResponseAsMap := new([]map[string]interface{})
 			nextPage := response.CurrentPage + 1
 
-			response, err = r.Page(nextPage).Get(nextJsonResponseAsMap)
+			previous := response
+			_, response, err = r.Page(nextPage).get(nextJsonResponseAsMap)
 			if err != nil {
 				return Response{}, err
 			}
+			response.mergeStale(previous)
 
 			*jsonResponseAsMap = append(*jsonResponseAsMap, *nextJsonResponseAsMap...)
 
@@ -78,11 +195,12 @@ func (r *Request) GetAll(value interface{}) (Response, error) {
 		return Response{}, err
 	}
 	err = json.Unmarshal(mergedJsonMapAsJson, value)
-	if err != nil {
+	if err != nil && !r.client.ignoresUnmarshalError(err) {
 		log.Printf("Failed to unmarshall merged response map to struct: %s", err)
 		return Response{}, err
 	}
 
+	r.client.reportSchemaDrift(r.endpoint(), mergedJsonMapAsJson, value, &response)
 	return response, nil
 }
 
@@ -103,11 +221,17 @@ func constructResponse(httpResponse *http.Response) Response {
 }
 
 func buildRequest(request *Request) (*http.Request, error) {
-	requestURL := request.client.baseURL.ResolveReference(&url.URL{Path: string(request.game) + "/" + request.path})
+	// The endpoint is an escaped path, which may contain escaped slashes that must not be resolved as such
+	endpoint := request.endpoint()
+	path, err := url.PathUnescape(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	requestURL := request.client.baseURL.ResolveReference(&url.URL{Path: path, RawPath: endpoint})
 	requestURL.RawQuery = setQueryParameters(request, requestURL.Query())
 
 	// Add the bearer token if it's set in the request
-	httpRequest, err := http.NewRequest("GET", requestURL.String(), nil)
+	httpRequest, err := http.NewRequestWithContext(request.context(), "GET", requestURL.String(), nil)
 	if err != nil {
 		return nil, err
 	} else {
@@ -124,6 +248,10 @@ func setQueryParameters(request *Request, query url.Values) string {
 	addSortingQueryParameter(request.sort, query)
 	addPagingQueryParameter(request.page, query)
 	addPageSizeQueryParameter(request.pageSize, query)
+	addSinceQueryParameter(request.since, query)
+	for parameter, values := range request.params {
+		query[parameter] = append(query[parameter], values...)
+	}
 	return query.Encode()
 }
 
@@ -153,6 +281,12 @@ func addPageSizeQueryParameter(pageSize int, query url.Values) {
 	}
 }
 
+func addSinceQueryParameter(since string, query url.Values) {
+	if len(since) > 0 {
+		query.Add("since", since)
+	}
+}
+
 func setAuthorizationHeader(request *Request, httpRequest *http.Request) {
 	if len(request.client.accessToken) > 0 {
 		httpRequest.Header.Add("Authorization", "Bearer "+request.client.accessToken)
@@ -161,25 +295,44 @@ func setAuthorizationHeader(request *Request, httpRequest *http.Request) {
 	}
 }
 
-func unmarshallResponseBody(response *http.Response, value interface{}) error {
+// Read the body of the given response. If the response isn't successful, the error message in the body is returned as
+// an error instead.
+func readResponseBody(response *http.Response) ([]byte, error) {
 	defer response.Body.Close()
 
-	// If the response is successful; unmarshal the body. If not; unmarshal the error message in the body.
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	if response.StatusCode >= 200 && response.StatusCode <= 299 {
-		return json.NewDecoder(response.Body).Decode(value)
+		return body, nil
+	} else if response.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
 	} else {
 		pandaScoreError := new(PandaScoreError)
-		err := json.NewDecoder(response.Body).Decode(pandaScoreError)
+		err := json.Unmarshal(body, pandaScoreError)
 		if err == nil {
+			pandaScoreError.StatusCode = response.StatusCode
 			err = pandaScoreError
 		}
-		return err
+		return nil, err
 	}
 }
 
+// With schema drift detection enabled, type mismatches are reported as drift instead of failing the request.
+func (c *Client) ignoresUnmarshalError(err error) bool {
+	_, isTypeError := err.(*json.UnmarshalTypeError)
+	return c.schemaDriftDetection && isTypeError
+}
+
+// Returned when the requested resource (eg. a match with a given ID or slug) doesn't exist in the PandaScore API.
+var ErrNotFound = errors.New("PandaScore error: resource not found")
+
 // Represents an error coming directly from the PandaScore API (eg. no or invalid access token).
 type PandaScoreError struct {
-	Message string `json:"error"`
+	Message    string `json:"error"`
+	StatusCode int    `json:"-"`
 }
 
 func (pse *PandaScoreError) Error() string {
@@ -1,6 +1,7 @@
 package pandascore
 
 import (
+	"io/ioutil"
 	"net/http"
 	"testing"
 
@@ -93,6 +94,13 @@ func TestRequest_Get_invalidGame(t *testing.T) {
 	assert.Contains(t, err.Error(), "doesn't exist")
 }
 
+func TestRequest_Get_missingGame(t *testing.T) {
+	var game Game
+	_, err := New().Request(game, "series/running").Get(nil)
+
+	assert.EqualError(t, err, "unknown game ''", "Expected a forgotten game not to hit the unprefixed endpoint")
+}
+
 func TestRequest_Get_missingAccessToken(t *testing.T) {
 	defer gock.Off()
 	defer assert.True(t, gock.IsDone())
@@ -106,6 +114,7 @@ func TestRequest_Get_missingAccessToken(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.IsType(t, &PandaScoreError{}, err)
 	assert.EqualError(t, err, "PandaScore error: Token is missing")
+	assert.Equal(t, http.StatusForbidden, err.(*PandaScoreError).StatusCode)
 }
 
 func TestRequest_Get_Filter(t *testing.T) {
@@ -246,3 +255,34 @@ func Test_constructResponse(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, Response{CurrentPage: 1, ResultsPerPage: 2, TotalResults: 3}, result)
 }
+
+func TestRequest_GetRaw(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		MatchParam("videogame_version", "latest").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json").
+		SetHeader("X-Total", "1")
+
+	body, response, err := New().Request(CSGO, "leagues").Param("videogame_version", "latest").GetRaw()
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 1, response.TotalResults)
+	expected, _ := ioutil.ReadFile("testdata/csgo-leagues-esl.json")
+	assert.Equal(t, expected, body)
+}
+
+func TestRequest_Get_BaseURL(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://localhost:8080/pandascore/csgo/leagues").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json")
+
+	_, err := New().BaseURL("http://localhost:8080/pandascore").Request(CSGO, "leagues").Get(new([]League))
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,134 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CSVWriter writes models as CSV records, one column per field.
+//
+// Columns are paths of JSON field names separated by dots, so nested objects and slices can be flattened, eg.
+// league.name or opponents.0.opponent.name. Columns that don't resolve to a value are left empty and objects or
+// slices are written as JSON. The header with the column names is written before the first record.
+type CSVWriter struct {
+	writer        *csv.Writer
+	columns       []string
+	headerWritten bool
+}
+
+// Construct a new CSV writer with the given columns. Without columns, all top-level fields of the first model that
+// aren't objects or slices are used, sorted by name.
+func NewCSVWriter(w io.Writer, columns ...string) *CSVWriter {
+	return &CSVWriter{writer: csv.NewWriter(w), columns: columns}
+}
+
+func (w *CSVWriter) Write(value interface{}) error {
+	document, err := toDocument(value)
+	if err != nil {
+		return err
+	}
+
+	if !w.headerWritten {
+		if len(w.columns) == 0 {
+			w.columns = scalarFields(document)
+		}
+		if err := w.writer.Write(w.columns); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	record := make([]string, len(w.columns))
+	for index, column := range w.columns {
+		if record[index], err = format(lookup(document, column)); err != nil {
+			return err
+		}
+	}
+	return w.writer.Write(record)
+}
+
+// Writes the header if no model was written yet, followed by any buffered records.
+func (w *CSVWriter) Flush() error {
+	if !w.headerWritten && len(w.columns) > 0 {
+		if err := w.writer.Write(w.columns); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// Convert the given model to its generic JSON representation, keeping numbers as they are.
+func toDocument(value interface{}) (interface{}, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var document interface{}
+	return document, decoder.Decode(&document)
+}
+
+// Returns the value at the given dot separated path in the given document, or nil if there is none.
+func lookup(document interface{}, path string) interface{} {
+	current := document
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			current = node[key]
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil
+			}
+			current = node[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+// Format the given JSON value as a CSV field.
+func format(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		content, err := json.Marshal(v)
+		return string(content), err
+	}
+}
+
+// Returns the sorted names of all fields of the given document that aren't objects or slices.
+func scalarFields(document interface{}) []string {
+	object, ok := document.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var fields []string
+	for field, value := range object {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+		default:
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+func readUpcomingMatches(t *testing.T) []pandascore.Match {
+	content, err := ioutil.ReadFile("../testdata/csgo-matches-upcoming.json")
+	assert.Nil(t, err)
+
+	var matches []pandascore.Match
+	assert.Nil(t, json.Unmarshal(content, &matches))
+	return matches
+}
+
+func readCSV(t *testing.T, output *bytes.Buffer) [][]string {
+	records, err := csv.NewReader(output).ReadAll()
+	assert.Nil(t, err)
+	return records
+}
+
+func TestCSVWriter(t *testing.T) {
+	output := new(bytes.Buffer)
+	writer := NewCSVWriter(output, "id", "name", "league.name", "opponents.0.opponent.name", "opponents.5.opponent.name",
+		"scheduled_at", "draw", "league")
+
+	assert.Nil(t, WriteAll(writer, readUpcomingMatches(t)))
+
+	records := readCSV(t, output)
+	assert.Len(t, records, 4)
+	assert.Equal(t, []string{"id", "name", "league.name", "opponents.0.opponent.name", "opponents.5.opponent.name",
+		"scheduled_at", "draw", "league"}, records[0])
+	assert.Equal(t, "556658", records[1][0])
+	assert.Equal(t, "FaZe vs forZe", records[1][1])
+	assert.Equal(t, "ESL", records[1][2])
+	assert.Equal(t, "FaZe", records[1][3])
+	assert.Equal(t, "", records[1][4], "Expected paths that don't resolve to be empty")
+	assert.Equal(t, "2020-04-04T12:25:00Z", records[1][5])
+	assert.Equal(t, "false", records[1][6])
+	assert.Contains(t, records[1][7], `"name":"ESL"`, "Expected objects to be written as JSON")
+}
+
+func TestCSVWriter_defaultColumns(t *testing.T) {
+	output := new(bytes.Buffer)
+	writer := NewCSVWriter(output)
+
+	league := pandascore.League{ID: 4158, Name: "ESL", Slug: "cs-go-esl", Modified: time.Date(2020, time.April, 23, 13, 0, 0, 0, time.UTC)}
+	assert.Nil(t, WriteAll(writer, []pandascore.League{league}))
+
+	records := readCSV(t, output)
+	assert.Equal(t, []string{"id", "image_url", "modified_at", "name", "series", "slug", "url"}, records[0])
+	assert.Equal(t, []string{"4158", "", "2020-04-23T13:00:00Z", "ESL", "", "cs-go-esl", ""}, records[1])
+}
+
+func TestCSVWriter_Flush(t *testing.T) {
+	output := new(bytes.Buffer)
+	assert.Nil(t, NewCSVWriter(output, "id", "name").Flush())
+	assert.Equal(t, "id,name\n", output.String(), "Expected the header to be written even without records")
+}
+
+func TestLookup(t *testing.T) {
+	document := map[string]interface{}{
+		"opponents": []interface{}{map[string]interface{}{"name": "FaZe"}},
+	}
+
+	assert.Equal(t, "FaZe", lookup(document, "opponents.0.name"))
+	assert.Nil(t, lookup(document, "opponents.one.name"))
+	assert.Nil(t, lookup(document, "opponents.-1.name"))
+	assert.Nil(t, lookup(document, "opponents.0.name.first"))
+	assert.Nil(t, lookup(document, "league.name"))
+}
@@ -0,0 +1,73 @@
+// Export PandaScore models to CSV and JSON Lines, eg. to load them into spreadsheets or data tools.
+//
+// Models can be written one by one, as a slice with WriteAll, or streamed page by page from a request with Stream so
+// large result sets never have to be kept in memory.
+package export
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// Writer writes models, one at a time, in a specific format.
+type Writer interface {
+	// Writes a single model, eg. a pandascore.Match
+	Write(value interface{}) error
+
+	// Writes any buffered data to the underlying writer
+	Flush() error
+}
+
+// Write every element of the given slice (or pointer to a slice) of models and flush the writer afterwards.
+func WriteAll(writer Writer, values interface{}) error {
+	slice := reflect.Indirect(reflect.ValueOf(values))
+	if slice.Kind() != reflect.Slice && slice.Kind() != reflect.Array {
+		return fmt.Errorf("expected a slice of models, got %T", values)
+	}
+
+	for index := 0; index < slice.Len(); index++ {
+		if err := writer.Write(slice.Index(index).Interface()); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// Execute the given request page by page and write every model to the given writer as soon as its page is fetched.
+// Page is a pointer to a slice of the model returned by the request, eg. new([]pandascore.Match), which is reused for
+// every page. Returns the number of models that were written.
+//
+// The request starts at its current page (the first page by default); use PageSize to limit the number of requests.
+func Stream(request *pandascore.Request, page interface{}, writer Writer) (int, error) {
+	pointer := reflect.ValueOf(page)
+	if pointer.Kind() != reflect.Ptr || pointer.Elem().Kind() != reflect.Slice {
+		return 0, fmt.Errorf("expected a pointer to a slice of models, got %T", page)
+	}
+	slice := pointer.Elem()
+
+	written := 0
+	for {
+		slice.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+		response, err := request.Get(page)
+		if err != nil {
+			return written, err
+		}
+
+		for index := 0; index < slice.Len(); index++ {
+			if err := writer.Write(slice.Index(index).Interface()); err != nil {
+				return written, err
+			}
+			written++
+		}
+		if err := writer.Flush(); err != nil {
+			return written, err
+		}
+
+		if !response.HasMore() || slice.Len() == 0 {
+			return written, nil
+		}
+		request.Page(response.CurrentPage + 1)
+	}
+}
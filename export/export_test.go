@@ -0,0 +1,75 @@
+package export
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestStream(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/series/running").
+		Reply(http.StatusOK).
+		File("../testdata/csgo-series-running.json").
+		SetHeader("X-Page", "1").
+		SetHeader("X-Per-Page", "2").
+		SetHeader("X-Total", "4")
+	gock.New("https://api.pandascore.co/csgo/series/running").
+		MatchParam("page[number]", "2").
+		Reply(http.StatusOK).
+		File("../testdata/csgo-series-running2.json").
+		SetHeader("X-Page", "2").
+		SetHeader("X-Per-Page", "2").
+		SetHeader("X-Total", "4")
+
+	output := new(bytes.Buffer)
+	request := pandascore.New().Request(pandascore.CSGO, "series/running").PageSize(2)
+	written, err := Stream(request, new([]pandascore.Series), NewCSVWriter(output, "id", "league.name"))
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 4, written)
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 5)
+	assert.Equal(t, "id,league.name", lines[0])
+	assert.Equal(t, "2522,ESL", lines[1])
+	assert.Equal(t, "2523,ESL", lines[3])
+}
+
+func TestStream_error(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/series/running").
+		Reply(http.StatusOK).
+		File("../testdata/csgo-series-running.json").
+		SetHeader("X-Page", "1").
+		SetHeader("X-Per-Page", "2").
+		SetHeader("X-Total", "4")
+	gock.New("https://api.pandascore.co/csgo/series/running").
+		MatchParam("page[number]", "2").
+		Reply(http.StatusInternalServerError).
+		JSON(map[string]string{"error": "Internal server error"})
+
+	output := new(bytes.Buffer)
+	request := pandascore.New().Request(pandascore.CSGO, "series/running")
+	written, err := Stream(request, new([]pandascore.Series), NewJSONLinesWriter(output))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, written)
+	assert.Equal(t, 2, strings.Count(output.String(), "\n"), "Expected the models of the first page to be written")
+}
+
+func TestStream_invalidPage(t *testing.T) {
+	_, err := Stream(pandascore.New().Request(pandascore.CSGO, "series"), []pandascore.Series{}, NewJSONLinesWriter(new(bytes.Buffer)))
+	assert.NotNil(t, err)
+}
+
+func TestWriteAll_invalidValues(t *testing.T) {
+	assert.NotNil(t, WriteAll(NewJSONLinesWriter(new(bytes.Buffer)), pandascore.League{}))
+}
@@ -0,0 +1,31 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// JSONLinesWriter writes every model as JSON on its own line.
+//
+// More information: https://jsonlines.org
+type JSONLinesWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+// Construct a new JSON Lines writer.
+func NewJSONLinesWriter(w io.Writer) *JSONLinesWriter {
+	writer := bufio.NewWriter(w)
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	return &JSONLinesWriter{writer: writer, encoder: encoder}
+}
+
+func (w *JSONLinesWriter) Write(value interface{}) error {
+	return w.encoder.Encode(value)
+}
+
+func (w *JSONLinesWriter) Flush() error {
+	return w.writer.Flush()
+}
@@ -0,0 +1,29 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+func TestJSONLinesWriter(t *testing.T) {
+	output := new(bytes.Buffer)
+	writer := NewJSONLinesWriter(output)
+
+	assert.Nil(t, writer.Write(pandascore.League{ID: 4158, Name: "ESL"}))
+	assert.Empty(t, output.String(), "Expected output to be buffered until flushed")
+	assert.Nil(t, writer.Write(pandascore.League{ID: 4159, Name: "<DreamHack>"}))
+	assert.Nil(t, writer.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], `"name":"<DreamHack>"`)
+
+	league := pandascore.League{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &league))
+	assert.Equal(t, 4158, league.ID)
+}
@@ -3,8 +3,11 @@ module github.com/tmbrggmn/pandascore-go
 go 1.14
 
 require (
+	github.com/gorilla/websocket v1.4.2
 	github.com/joho/godotenv v1.3.0
+	github.com/mattn/go-sqlite3 v1.14.6
 	github.com/olekukonko/tablewriter v0.0.4
 	github.com/stretchr/testify v1.5.1
+	golang.org/x/term v0.0.0-20201126162022-7de9c90e9dd1
 	gopkg.in/h2non/gock.v1 v1.0.15
 )
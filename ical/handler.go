@@ -0,0 +1,107 @@
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// Handler serves the upcoming matches of a league or team as an iCalendar feed:
+//
+//	/leagues/{id or slug}.ics
+//	/teams/{id}.ics
+//
+// Mount it with http.StripPrefix to serve it under another path.
+type Handler struct {
+	client   *pandascore.Client
+	calendar *Calendar
+}
+
+// Construct a new handler that fetches upcoming matches with the given client.
+func NewHandler(client *pandascore.Client) *Handler {
+	return &Handler{client: client, calendar: New()}
+}
+
+// Sets the calendar used to encode the feeds, eg. one that keeps its revisions in a file.
+func (h *Handler) Calendar(calendar *Calendar) *Handler {
+	h.calendar = calendar
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 2 || !strings.HasSuffix(parts[1], ".ics") {
+		http.NotFound(w, r)
+		return
+	}
+	id := strings.TrimSuffix(parts[1], ".ics")
+
+	var name string
+	var matches []pandascore.Match
+	var err error
+	switch parts[0] {
+	case "leagues":
+		matches, err = h.client.GetLeagueUpcomingMatches(id)
+		name = leagueName(id, matches)
+	case "teams":
+		teamID, convErr := strconv.Atoi(id)
+		if convErr != nil {
+			http.NotFound(w, r)
+			return
+		}
+		matches, err = h.client.GetAllUpcomingMatchesForTeam(teamID)
+		name = teamName(teamID, matches)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if errors.Is(err, pandascore.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("failed to fetch upcoming PandaScore matches for %s: %s", r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := h.calendar.Encode(&body, name, matches); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="`+parts[0]+"-"+id+`.ics"`)
+	_, _ = w.Write(body.Bytes())
+}
+
+// Returns the name of the league the matches belong to, falling back to the given ID or slug.
+func leagueName(idOrSlug string, matches []pandascore.Match) string {
+	if len(matches) > 0 && len(matches[0].League.Name) > 0 {
+		return matches[0].League.Name
+	}
+	return idOrSlug
+}
+
+// Returns the name of the team with the given ID as found among the opponents of the matches, falling back to the ID.
+func teamName(id int, matches []pandascore.Match) string {
+	for _, match := range matches {
+		for _, opponent := range match.Opponents {
+			if opponent.Opponent.ID == id {
+				return opponent.Opponent.Name
+			}
+		}
+	}
+	return strconv.Itoa(id)
+}
@@ -0,0 +1,67 @@
+package ical
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func serve(method string, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	NewHandler(pandascore.New().AccessToken("test")).ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+	return recorder
+}
+
+func TestHandler_league(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/leagues/4158/matches/upcoming").
+		Reply(http.StatusOK).
+		File("../testdata/csgo-matches-upcoming.json")
+
+	response := serve(http.MethodGet, "/leagues/4158.ics")
+
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.Contains(t, response.Body.String(), "X-WR-CALNAME:ESL\r\n")
+	assert.Equal(t, 3, strings.Count(response.Body.String(), "BEGIN:VEVENT"))
+}
+
+func TestHandler_team(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/matches/upcoming").
+		MatchParam("filter[opponent_id]", "3212").
+		Reply(http.StatusOK).
+		File("../testdata/csgo-matches-upcoming.json")
+
+	response := serve(http.MethodGet, "/teams/3212.ics")
+
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), "X-WR-CALNAME:FaZe\r\n")
+}
+
+func TestHandler_errors(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/leagues/unknown/matches/upcoming").
+		Reply(http.StatusNotFound).
+		File("../testdata/error-not-found.json")
+	gock.New("https://api.pandascore.co/leagues/4158/matches/upcoming").
+		Reply(http.StatusInternalServerError).
+		JSON(map[string]string{"error": "Internal server error"})
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/leagues/unknown.ics").Code)
+	assert.Equal(t, http.StatusBadGateway, serve(http.MethodGet, "/leagues/4158.ics").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/teams/faze.ics").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/players/1.ics").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/leagues/4158").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPost, "/leagues/4158.ics").Code)
+}
@@ -0,0 +1,268 @@
+// Convert PandaScore matches into iCalendar (RFC 5545) feeds, so match schedules can be subscribed to from calendar
+// apps.
+//
+// More information: https://tools.ietf.org/html/rfc5545
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+const (
+	// Product identifier of the generated feeds
+	ProductID = "-//tmbrggmn//pandascore-go//EN"
+
+	// Domain used in the UID of every event; UIDs are stable per match ID
+	UIDDomain = "pandascore.co"
+
+	// Estimated duration of a single game, used for the end of matches that haven't ended yet
+	DefaultGameDuration = time.Hour
+
+	// Time after which the revision of a match that has ended is forgotten
+	RevisionRetention = 30 * 24 * time.Hour
+)
+
+// Maximum length of a content line in octets, excluding the line break.
+const maxLineLength = 75
+
+// Calendar converts matches into iCalendar feeds.
+//
+// Calendar apps only pick up changes to an event if its SEQUENCE increases, so the calendar keeps a revision of every
+// match: the start and end it was last seen with, and a sequence that's bumped whenever either of them changes. Other
+// changes, like a new score, leave the sequence alone. Revisions are kept in memory by default; use a FileRevisionStore
+// to keep sequences across restarts. Revisions of matches that ended more than RevisionRetention ago are forgotten.
+type Calendar struct {
+	mutex sync.Mutex
+	store RevisionStore
+	now   func() time.Time
+}
+
+// Construct a new calendar that keeps revisions in memory.
+func New() *Calendar {
+	return &Calendar{store: NewMemoryRevisionStore(), now: time.Now}
+}
+
+// Sets the store used to keep the revisions of matches.
+func (c *Calendar) Store(store RevisionStore) *Calendar {
+	c.store = store
+	return c
+}
+
+// Write the given matches as an iCalendar feed with the given name to the given writer.
+func (c *Calendar) Encode(w io.Writer, name string, matches []pandascore.Match) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + ProductID,
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	if len(name) > 0 {
+		lines = append(lines, "X-WR-CALNAME:"+escapeText(name))
+	}
+
+	sequences, err := c.revise(matches)
+	if err != nil {
+		return err
+	}
+
+	stamp := formatTime(c.now())
+	for _, match := range matches {
+		start := startOf(match)
+		if start.IsZero() {
+			continue
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:match-%d@%s", match.ID, UIDDomain),
+			fmt.Sprintf("SEQUENCE:%d", sequences[match.ID]),
+			"DTSTAMP:"+stamp,
+			"DTSTART:"+formatTime(start),
+			"DTEND:"+formatTime(endOf(match, start)),
+			"SUMMARY:"+escapeText(match.Name),
+			"DESCRIPTION:"+escapeText(describe(match)),
+			"STATUS:"+status(match),
+		)
+		if !match.Modified.IsZero() {
+			lines = append(lines, "LAST-MODIFIED:"+formatTime(match.Modified))
+		}
+		if urls := streamURLs(match); len(urls) > 0 {
+			lines = append(lines, "URL:"+urls[0])
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(fold(line))
+		builder.WriteString("\r\n")
+	}
+	_, err = io.WriteString(w, builder.String())
+	return err
+}
+
+// Returns the sequences of the given matches keyed by ID, after bumping the ones of the matches whose start or end
+// changed since they were last seen.
+func (c *Calendar) revise(matches []pandascore.Match) (map[int]int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	revisions, err := c.store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	sequences := make(map[int]int, len(matches))
+	for _, match := range matches {
+		start := startOf(match)
+		if start.IsZero() {
+			continue
+		}
+		end := endOf(match, start)
+
+		revision, ok := revisions[match.ID]
+		if !ok || !revision.Start.Equal(start) || !revision.End.Equal(end) {
+			if ok {
+				revision.Sequence++
+			}
+			revision.Start, revision.End = start, end
+			revisions[match.ID] = revision
+			changed = true
+		}
+		sequences[match.ID] = revision.Sequence
+	}
+
+	cutoff := c.now().Add(-RevisionRetention)
+	for id, revision := range revisions {
+		if revision.End.Before(cutoff) {
+			delete(revisions, id)
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := c.store.Save(revisions); err != nil {
+			return nil, err
+		}
+	}
+	return sequences, nil
+}
+
+// Returns when the match starts: the time it's scheduled at, or the time it began if it isn't scheduled.
+func startOf(match pandascore.Match) time.Time {
+	if !match.ScheduledAt.IsZero() {
+		return match.ScheduledAt
+	}
+	return match.BeginsAt
+}
+
+// Returns when the match ends, estimated from the number of games if it hasn't ended yet.
+func endOf(match pandascore.Match, start time.Time) time.Time {
+	if !match.EndsAt.IsZero() && match.EndsAt.After(start) {
+		return match.EndsAt
+	}
+	games := match.NumberOfGames
+	if games < 1 {
+		games = 1
+	}
+	return start.Add(time.Duration(games) * DefaultGameDuration)
+}
+
+func status(match pandascore.Match) string {
+	switch match.Status {
+	case "canceled":
+		return "CANCELLED"
+	case "postponed":
+		return "TENTATIVE"
+	default:
+		return "CONFIRMED"
+	}
+}
+
+// Returns the description of the match: where it's played and where it can be watched.
+func describe(match pandascore.Match) string {
+	var lines []string
+	event := match.League.Name
+	if len(match.Series.FullName) > 0 {
+		event = strings.TrimSpace(event + " " + match.Series.FullName)
+	}
+	if len(match.Tournament.Name) > 0 {
+		event += " - " + match.Tournament.Name
+	}
+	if len(event) > 0 {
+		lines = append(lines, event)
+	}
+	if match.NumberOfGames > 0 {
+		lines = append(lines, fmt.Sprintf("Best of %d", match.NumberOfGames))
+	}
+	if urls := streamURLs(match); len(urls) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "Streams:")
+		lines = append(lines, urls...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the URLs of all streams of the match without duplicates, main stream first.
+func streamURLs(match pandascore.Match) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(url string) {
+		if len(url) > 0 && !seen[url] {
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+
+	for _, stream := range match.Streams {
+		if stream.Main {
+			add(stream.RawURL)
+		}
+	}
+	add(match.LiveURL)
+	for _, stream := range match.Streams {
+		add(stream.RawURL)
+	}
+	return urls
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// Escape the given value for use in a TEXT property.
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// Fold the given content line into lines of at most 75 octets, without splitting multi-byte characters. Continuation
+// lines start with a single space.
+func fold(line string) string {
+	if len(line) <= maxLineLength {
+		return line
+	}
+
+	var builder strings.Builder
+	length := 0
+	for _, character := range line {
+		size := len(string(character))
+		if length+size > maxLineLength {
+			builder.WriteString("\r\n ")
+			length = 1
+		}
+		builder.WriteRune(character)
+		length += size
+	}
+	return builder.String()
+}
@@ -0,0 +1,151 @@
+package ical
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+func readUpcomingMatches(t *testing.T) []pandascore.Match {
+	content, err := ioutil.ReadFile("../testdata/csgo-matches-upcoming.json")
+	assert.Nil(t, err)
+
+	var matches []pandascore.Match
+	assert.Nil(t, json.Unmarshal(content, &matches))
+	return matches
+}
+
+func newTestCalendar() *Calendar {
+	calendar := New()
+	calendar.now = func() time.Time { return time.Date(2020, time.April, 1, 10, 0, 0, 0, time.UTC) }
+	return calendar
+}
+
+func encode(t *testing.T, calendar *Calendar, name string, matches []pandascore.Match) string {
+	output := new(bytes.Buffer)
+	assert.Nil(t, calendar.Encode(output, name, matches))
+	return output.String()
+}
+
+func TestCalendar_Encode(t *testing.T) {
+	output := encode(t, newTestCalendar(), "ESL Pro League", readUpcomingMatches(t))
+
+	assert.True(t, strings.HasPrefix(output, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:"+ProductID+"\r\n"))
+	assert.True(t, strings.HasSuffix(output, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Contains(t, output, "X-WR-CALNAME:ESL Pro League\r\n")
+	assert.Equal(t, 3, strings.Count(output, "BEGIN:VEVENT\r\n"))
+
+	assert.Contains(t, output, "UID:match-556658@pandascore.co\r\n"+
+		"SEQUENCE:0\r\n"+
+		"DTSTAMP:20200401T100000Z\r\n"+
+		"DTSTART:20200404T122500Z\r\n"+
+		"DTEND:20200404T152500Z\r\n"+
+		"SUMMARY:FaZe vs forZe\r\n"+
+		"DESCRIPTION:ESL Pro League season 11 2020 - Play-in\\nBest of 3\r\n"+
+		"STATUS:CONFIRMED\r\n")
+
+	for _, line := range strings.Split(output, "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineLength)
+	}
+}
+
+func TestCalendar_Encode_streams(t *testing.T) {
+	match := pandascore.Match{
+		ID:          559177,
+		Name:        "FaZe vs North",
+		ScheduledAt: time.Date(2020, time.April, 23, 13, 0, 0, 0, time.UTC),
+		EndsAt:      time.Date(2020, time.April, 23, 15, 30, 0, 0, time.UTC),
+		LiveURL:     "https://www.twitch.tv/esl_csgo",
+		Streams: []pandascore.MatchStream{
+			{RawURL: "https://www.twitch.tv/esl_csgo", Official: true},
+			{RawURL: "https://www.youtube.com/esl", Main: true},
+		},
+	}
+
+	output := strings.ReplaceAll(encode(t, newTestCalendar(), "", []pandascore.Match{match}), "\r\n ", "")
+
+	assert.NotContains(t, output, "X-WR-CALNAME")
+	assert.Contains(t, output, "DTEND:20200423T153000Z\r\n")
+	assert.Contains(t, output, "DESCRIPTION:Streams:\\nhttps://www.youtube.com/esl\\nhttps://www.twitch.tv/esl_csgo\r\n")
+	assert.Contains(t, output, "URL:https://www.youtube.com/esl\r\n")
+}
+
+func TestCalendar_Encode_rescheduled(t *testing.T) {
+	calendar := newTestCalendar()
+	matches := readUpcomingMatches(t)
+
+	assert.Contains(t, encode(t, calendar, "", matches), "UID:match-556658@pandascore.co\r\nSEQUENCE:0\r\n")
+	matches[0].Modified = matches[0].Modified.Add(time.Minute)
+	matches[0].Results = []pandascore.MatchResult{{TeamID: 3212, Score: 1}}
+	assert.Contains(t, encode(t, calendar, "", matches), "UID:match-556658@pandascore.co\r\nSEQUENCE:0\r\n",
+		"Expected the sequence not to change if the match wasn't rescheduled")
+
+	matches[0].ScheduledAt = matches[0].ScheduledAt.Add(time.Hour)
+	output := encode(t, calendar, "", matches)
+	assert.Contains(t, output, "UID:match-556658@pandascore.co\r\nSEQUENCE:1\r\n")
+	assert.Contains(t, output, "DTSTART:20200404T132500Z\r\n")
+	assert.Contains(t, output, "UID:match-557708@pandascore.co\r\nSEQUENCE:0\r\n")
+
+	matches[1].NumberOfGames++
+	output = encode(t, calendar, "", matches)
+	assert.Contains(t, output, "UID:match-557708@pandascore.co\r\nSEQUENCE:1\r\n", "Expected a new end to count as well")
+}
+
+func TestCalendar_Store(t *testing.T) {
+	store := NewMemoryRevisionStore()
+	matches := readUpcomingMatches(t)
+	encode(t, newTestCalendar().Store(store), "", matches)
+
+	matches[0].ScheduledAt = matches[0].ScheduledAt.Add(time.Hour)
+	output := encode(t, newTestCalendar().Store(store), "", matches)
+	assert.Contains(t, output, "UID:match-556658@pandascore.co\r\nSEQUENCE:1\r\n",
+		"Expected the revisions to be shared by calendars with the same store")
+
+	later := matches[2].ScheduledAt.Add(RevisionRetention + 24*time.Hour)
+	calendar := newTestCalendar().Store(store)
+	calendar.now = func() time.Time { return later }
+	encode(t, calendar, "", []pandascore.Match{{ID: 1, ScheduledAt: later}})
+	revisions, _ := store.Load()
+	assert.Equal(t, []int{1}, revisionIDs(revisions),
+		"Expected the revisions of matches that ended long ago to be forgotten")
+}
+
+func revisionIDs(revisions map[int]Revision) []int {
+	var ids []int
+	for id := range revisions {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestCalendar_Encode_canceled(t *testing.T) {
+	match := pandascore.Match{ID: 1, Status: "canceled", BeginsAt: time.Date(2020, time.April, 23, 13, 0, 0, 0, time.UTC)}
+	unscheduled := pandascore.Match{ID: 2}
+
+	output := encode(t, newTestCalendar(), "", []pandascore.Match{match, unscheduled})
+
+	assert.Contains(t, output, "STATUS:CANCELLED\r\n")
+	assert.Contains(t, output, "DTEND:20200423T140000Z\r\n")
+	assert.NotContains(t, output, "match-2@", "Expected matches without a start time to be skipped")
+}
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, `Group A\, Day 1\; round 2\nfinal \\ decider`, escapeText("Group A, Day 1; round 2\nfinal \\ decider"))
+}
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "SUMMARY:short", fold("SUMMARY:short"))
+
+	line := "DESCRIPTION:" + strings.Repeat("é", 50)
+	folded := fold(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		assert.LessOrEqual(t, len(part), maxLineLength)
+	}
+	assert.Equal(t, line, strings.ReplaceAll(folded, "\r\n ", ""))
+}
@@ -0,0 +1,99 @@
+package ical
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// Revision is the schedule a match was last seen with and how often it was rescheduled, which is its SEQUENCE.
+type Revision struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Sequence int       `json:"sequence"`
+}
+
+// RevisionStore keeps the revisions of all matches in a calendar keyed by match ID, so their sequences survive a
+// restart and can be shared by every process that serves the same feeds.
+type RevisionStore interface {
+	// Returns all revisions, or an empty map if there are none yet
+	Load() (map[int]Revision, error)
+
+	// Replaces all revisions
+	Save(revisions map[int]Revision) error
+}
+
+// MemoryRevisionStore keeps revisions in memory, so they're lost when the program stops.
+type MemoryRevisionStore struct {
+	mutex     sync.Mutex
+	revisions map[int]Revision
+}
+
+// Construct a new, empty in-memory revision store.
+func NewMemoryRevisionStore() *MemoryRevisionStore {
+	return &MemoryRevisionStore{revisions: make(map[int]Revision)}
+}
+
+func (s *MemoryRevisionStore) Load() (map[int]Revision, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return copyRevisions(s.revisions), nil
+}
+
+func (s *MemoryRevisionStore) Save(revisions map[int]Revision) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.revisions = copyRevisions(revisions)
+	return nil
+}
+
+// FileRevisionStore keeps revisions as JSON in a single file.
+type FileRevisionStore struct {
+	mutex sync.Mutex
+	path  string
+}
+
+// Construct a new store that keeps revisions in the file at the given path. The file is created when revisions are
+// first saved.
+func NewFileRevisionStore(path string) *FileRevisionStore {
+	return &FileRevisionStore{path: path}
+}
+
+func (s *FileRevisionStore) Load() (map[int]Revision, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	revisions := make(map[int]Revision)
+	content, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return revisions, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return revisions, json.Unmarshal(content, &revisions)
+}
+
+func (s *FileRevisionStore) Save(revisions map[int]Revision) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Write to a temporary file first and move it in place, so a crash halfway doesn't leave a corrupt file behind
+	content, err := json.MarshalIndent(revisions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(s.path+".tmp", content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(s.path+".tmp", s.path)
+}
+
+func copyRevisions(revisions map[int]Revision) map[int]Revision {
+	result := make(map[int]Revision, len(revisions))
+	for id, revision := range revisions {
+		result[id] = revision
+	}
+	return result
+}
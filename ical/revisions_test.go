@@ -0,0 +1,33 @@
+package ical
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileRevisionStore(t *testing.T) {
+	directory, _ := ioutil.TempDir("", "pandascore")
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "revisions.json")
+
+	store := NewFileRevisionStore(path)
+	revisions, err := store.Load()
+	assert.Nil(t, err)
+	assert.Empty(t, revisions, "Expected no revisions before the file exists")
+
+	start := time.Date(2020, time.April, 4, 12, 25, 0, 0, time.UTC)
+	saved := map[int]Revision{556658: {Start: start, End: start.Add(3 * time.Hour), Sequence: 2}}
+	assert.Nil(t, store.Save(saved))
+
+	revisions, err = NewFileRevisionStore(path).Load()
+	assert.Nil(t, err)
+	assert.Equal(t, saved, revisions)
+
+	files, _ := ioutil.ReadDir(directory)
+	assert.Len(t, files, 1, "Expected the temporary file to be moved in place")
+}
@@ -0,0 +1,314 @@
+package pandascore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// Feed of all additions, changes and deletions
+	IncidentsFeed Feed = "incidents"
+
+	// Feed of newly created resources
+	AdditionsFeed Feed = "additions"
+
+	// Feed of updated resources
+	ChangesFeed Feed = "changes"
+
+	// Feed of deleted resources
+	DeletionsFeed Feed = "deletions"
+)
+
+const (
+	MatchIncident      IncidentType = "match"
+	SeriesIncident     IncidentType = "serie"
+	LeagueIncident     IncidentType = "league"
+	TournamentIncident IncidentType = "tournament"
+	TeamIncident       IncidentType = "team"
+	PlayerIncident     IncidentType = "player"
+)
+
+// Default time between two polls of an incident feed
+const DefaultIncidentPollInterval = time.Minute
+
+// Feed is one of the PandaScore change feeds, which list resources that were added, changed or deleted.
+//
+// More information: https://developers.pandascore.co/doc/#tag/Incidents
+type Feed string
+
+// IncidentType is the type of resource an incident is about.
+type IncidentType string
+
+// Incident represents a single addition, change or deletion of a resource.
+//
+// Object contains the resource as it was after the change, or a Deletion for deletions. Use Decode to decode it into
+// the model of its type.
+type Incident struct {
+	ID         int             `json:"id"`
+	Type       IncidentType    `json:"type"`
+	ChangeType string          `json:"change_type"`
+	Modified   time.Time       `json:"modified_at"`
+	Object     json.RawMessage `json:"object"`
+}
+
+// Deletion describes a deleted resource, which is the object of every deletion incident.
+type Deletion struct {
+	ID          int          `json:"id"`
+	Type        IncidentType `json:"type"`
+	Reason      string       `json:"reason"`
+	DeletedAt   time.Time    `json:"deleted_at"`
+	VideogameID int          `json:"videogame_id"`
+}
+
+// Returns true if this incident is about a deleted resource.
+func (i *Incident) IsDeletion() bool {
+	return i.ChangeType == "deletion"
+}
+
+// Decode the object of the incident into the model of its type, eg. *Match for match incidents or *Deletion for all
+// deletions. Objects of unknown types are returned as raw JSON.
+func (i *Incident) Decode() (interface{}, error) {
+	var value interface{}
+	switch {
+	case i.IsDeletion():
+		value = new(Deletion)
+	case i.Type == MatchIncident:
+		value = new(Match)
+	case i.Type == SeriesIncident:
+		value = new(Series)
+	case i.Type == LeagueIncident:
+		value = new(League)
+	case i.Type == TournamentIncident:
+		value = new(Tournament)
+	case i.Type == TeamIncident:
+		value = new(Team)
+	case i.Type == PlayerIncident:
+		value = new(Player)
+	default:
+		return i.Object, nil
+	}
+
+	if err := json.Unmarshal(i.Object, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Returns all incidents of the given feed that happened since the given time, ordered from oldest to newest. Incidents
+// can be limited to the given types; if no types are given incidents of all types are returned.
+func (c *Client) GetAllIncidents(feed Feed, since time.Time, types ...IncidentType) ([]Incident, error) {
+	request := c.RequestAllGames(string(feed)).Since(since).PageSize(100)
+	if len(types) > 0 {
+		values := make([]string, len(types))
+		for index, incidentType := range types {
+			values[index] = string(incidentType)
+		}
+		request.Filter("type", values...)
+	}
+
+	incidents := new([]Incident)
+	_, err := request.GetAll(incidents)
+	sort.SliceStable(*incidents, func(i, j int) bool {
+		return (*incidents)[i].Modified.Before((*incidents)[j].Modified)
+	})
+	return *incidents, err
+}
+
+// IncidentHandler handles incidents delivered by an IncidentPoller.
+type IncidentHandler interface {
+	HandleIncident(incident Incident) error
+}
+
+// IncidentHandlerFunc allows ordinary functions to be used as an IncidentHandler.
+type IncidentHandlerFunc func(incident Incident) error
+
+func (f IncidentHandlerFunc) HandleIncident(incident Incident) error {
+	return f(incident)
+}
+
+// CursorStore persists up until when the incidents of a feed have been handled, so polling can resume from there.
+type CursorStore interface {
+	// Returns the cursor of the given feed, or the zero time if there is none yet
+	Load(feed Feed) (time.Time, error)
+
+	// Stores the cursor of the given feed
+	Save(feed Feed, cursor time.Time) error
+}
+
+// IncidentPoller keeps track of a PandaScore change feed by polling it at an interval and passing all new incidents to
+// a handler.
+//
+// Incidents are delivered at least once: PandaScore includes incidents modified at exactly the cursor time, so the
+// last incident of a poll can be delivered again in the next one.
+type IncidentPoller struct {
+	client   *Client
+	feed     Feed
+	handler  IncidentHandler
+	store    CursorStore
+	types    []IncidentType
+	interval time.Duration
+}
+
+// Construct a new poller for the given feed that passes every incident to the given handler. By default the cursor is
+// only kept in memory; use Store to persist it.
+func (c *Client) IncidentPoller(feed Feed, handler IncidentHandler) *IncidentPoller {
+	return &IncidentPoller{
+		client:   c,
+		feed:     feed,
+		handler:  handler,
+		store:    NewMemoryCursorStore(),
+		interval: DefaultIncidentPollInterval,
+	}
+}
+
+// Sets the store used to load and save the cursor of the feed.
+func (p *IncidentPoller) Store(store CursorStore) *IncidentPoller {
+	p.store = store
+	return p
+}
+
+// Limits the incidents to the given types.
+func (p *IncidentPoller) Types(types ...IncidentType) *IncidentPoller {
+	p.types = types
+	return p
+}
+
+// Sets the time between two polls. The interval must be larger than 0.
+func (p *IncidentPoller) Interval(interval time.Duration) *IncidentPoller {
+	if interval > 0 {
+		p.interval = interval
+	}
+	return p
+}
+
+// Fetch all incidents since the stored cursor and pass them to the handler, oldest first. The cursor is saved after
+// every poll, up until the last incident that was handled successfully. If the handler fails, polling stops and the
+// error is returned so the failed incident is delivered again on the next poll.
+func (p *IncidentPoller) Poll() error {
+	cursor, err := p.store.Load(p.feed)
+	if err != nil {
+		return err
+	}
+
+	incidents, err := p.client.GetAllIncidents(p.feed, cursor, p.types...)
+	if err != nil {
+		return err
+	}
+
+	var handlerErr error
+	for _, incident := range incidents {
+		if handlerErr = p.handler.HandleIncident(incident); handlerErr != nil {
+			handlerErr = fmt.Errorf("failed to handle %s incident %d: %w", incident.Type, incident.ID, handlerErr)
+			break
+		}
+		if incident.Modified.After(cursor) {
+			cursor = incident.Modified
+		}
+	}
+
+	if err := p.store.Save(p.feed, cursor); err != nil {
+		return err
+	}
+	return handlerErr
+}
+
+// Poll the feed at the configured interval until the given context is done. Failed polls are logged and retried on
+// the next interval.
+func (p *IncidentPoller) Run(ctx context.Context) {
+	for {
+		if err := p.Poll(); err != nil {
+			log.Printf("failed to poll PandaScore %s: %s", p.feed, err)
+		}
+
+		select {
+		case <-time.After(p.interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// MemoryCursorStore keeps cursors in memory, so they're lost when the program stops.
+type MemoryCursorStore struct {
+	mutex   sync.Mutex
+	cursors map[Feed]time.Time
+}
+
+// Construct a new, empty in-memory cursor store.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: make(map[Feed]time.Time)}
+}
+
+func (s *MemoryCursorStore) Load(feed Feed) (time.Time, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cursors[feed], nil
+}
+
+func (s *MemoryCursorStore) Save(feed Feed, cursor time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cursors[feed] = cursor
+	return nil
+}
+
+// FileCursorStore keeps the cursors of all feeds in a single JSON file.
+type FileCursorStore struct {
+	mutex sync.Mutex
+	path  string
+}
+
+// Construct a new cursor store that reads and writes the JSON file at the given path. The file is created when the
+// first cursor is saved.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+func (s *FileCursorStore) Load(feed Feed) (time.Time, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cursors, err := s.read()
+	return cursors[feed], err
+}
+
+func (s *FileCursorStore) Save(feed Feed, cursor time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return err
+	}
+	cursors[feed] = cursor
+
+	// Write to a temporary file first and move it in place, so a crash halfway doesn't leave a corrupt file behind
+	content, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(s.path+".tmp", content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(s.path+".tmp", s.path)
+}
+
+func (s *FileCursorStore) read() (map[Feed]time.Time, error) {
+	cursors := make(map[Feed]time.Time)
+
+	content, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return cursors, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return cursors, json.Unmarshal(content, &cursors)
+}
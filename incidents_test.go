@@ -0,0 +1,153 @@
+package pandascore
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClient_GetAllIncidents(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/incidents").
+		MatchParam("since", "2020-04-23T13:00:00Z").
+		MatchParam("filter[type]", "match,serie").
+		Reply(http.StatusOK).
+		File("testdata/incidents.json")
+
+	client := New()
+	result, err := client.GetAllIncidents(IncidentsFeed, time.Date(2020, time.April, 23, 13, 0, 0, 0, time.UTC), MatchIncident, SeriesIncident)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 4)
+	assert.Equal(t, []int{2522, 4158, 556012, 559177}, []int{result[0].ID, result[1].ID, result[2].ID, result[3].ID},
+		"Expected incidents to be ordered from oldest to newest")
+	assert.Equal(t, SeriesIncident, result[0].Type)
+	assert.Equal(t, "creation", result[0].ChangeType)
+	assert.True(t, result[2].IsDeletion())
+}
+
+func TestIncident_Decode(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/changes").
+		Reply(http.StatusOK).
+		File("testdata/incidents.json")
+
+	incidents, _ := New().GetAllIncidents(ChangesFeed, time.Time{})
+
+	series, err := incidents[0].Decode()
+	assert.Nil(t, err)
+	assert.IsType(t, &Series{}, series)
+	assert.Equal(t, "ANZ Champs: Online Stage", series.(*Series).Name)
+
+	league, err := incidents[1].Decode()
+	assert.Nil(t, err)
+	assert.IsType(t, &League{}, league)
+	assert.Equal(t, "ESL", league.(*League).Name)
+
+	deletion, err := incidents[2].Decode()
+	assert.Nil(t, err)
+	assert.Equal(t,
+		&Deletion{ID: 556012, Type: MatchIncident, Reason: "Duplicate", DeletedAt: time.Date(2020, time.April, 23, 13, 4, 55, 0, time.UTC), VideogameID: 3},
+		deletion,
+	)
+
+	match, err := incidents[3].Decode()
+	assert.Nil(t, err)
+	assert.IsType(t, &Match{}, match)
+	assert.Equal(t, "FaZe vs North", match.(*Match).Name)
+}
+
+func TestIncident_Decode_unknownType(t *testing.T) {
+	incident := Incident{Type: IncidentType("videogame"), Object: []byte(`{"id":3}`)}
+
+	result, err := incident.Decode()
+
+	assert.Nil(t, err)
+	assert.Equal(t, incident.Object, result)
+}
+
+func TestIncidentPoller_Poll(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/additions").
+		Reply(http.StatusOK).
+		File("testdata/incidents.json")
+	gock.New("https://api.pandascore.co/additions").
+		MatchParam("since", "2020-04-23T13:05:12Z").
+		Reply(http.StatusOK).
+		BodyString("[]")
+
+	var handled []int
+	store := NewMemoryCursorStore()
+	poller := New().
+		IncidentPoller(AdditionsFeed, IncidentHandlerFunc(func(incident Incident) error {
+			handled = append(handled, incident.ID)
+			return nil
+		})).
+		Store(store)
+
+	assert.Nil(t, poller.Poll())
+	assert.Equal(t, []int{2522, 4158, 556012, 559177}, handled)
+	cursor, _ := store.Load(AdditionsFeed)
+	assert.Equal(t, time.Date(2020, time.April, 23, 13, 5, 12, 0, time.UTC), cursor)
+
+	assert.Nil(t, poller.Poll(), "Expected second poll to continue from the stored cursor")
+	assert.True(t, gock.IsDone())
+}
+
+func TestIncidentPoller_Poll_handlerFails(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/incidents").
+		Reply(http.StatusOK).
+		File("testdata/incidents.json")
+
+	store := NewMemoryCursorStore()
+	err := New().
+		IncidentPoller(IncidentsFeed, IncidentHandlerFunc(func(incident Incident) error {
+			if incident.IsDeletion() {
+				return errors.New("can't handle deletions")
+			}
+			return nil
+		})).
+		Store(store).
+		Poll()
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "can't handle deletions")
+	cursor, _ := store.Load(IncidentsFeed)
+	assert.Equal(t, time.Date(2020, time.April, 23, 13, 3, 2, 0, time.UTC), cursor,
+		"Expected cursor to be saved up until the last incident that was handled")
+}
+
+func TestFileCursorStore(t *testing.T) {
+	directory, _ := ioutil.TempDir("", "pandascore")
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "cursors.json")
+
+	store := NewFileCursorStore(path)
+	cursor, err := store.Load(IncidentsFeed)
+	assert.Nil(t, err)
+	assert.True(t, cursor.IsZero(), "Expected zero cursor before the file exists")
+
+	saved := time.Date(2020, time.April, 23, 13, 5, 12, 0, time.UTC)
+	assert.Nil(t, store.Save(IncidentsFeed, saved))
+	assert.Nil(t, store.Save(DeletionsFeed, saved.Add(time.Hour)))
+
+	cursor, err = NewFileCursorStore(path).Load(IncidentsFeed)
+	assert.Nil(t, err)
+	assert.Equal(t, saved, cursor)
+
+	files, _ := ioutil.ReadDir(directory)
+	assert.Len(t, files, 1, "Expected the temporary file to be moved in place")
+}
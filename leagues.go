@@ -2,6 +2,13 @@ package pandascore
 
 import "time"
 
+// Returns the league with the given ID or slug.
+func (c *Client) GetLeague(idOrSlug string) (League, error) {
+	league := new(League)
+	_, err := c.RequestAllGames(resourcePath("leagues", idOrSlug)).Get(league)
+	return *league, err
+}
+
 // Returns all known leagues for the given game.
 func (c *Client) GetAllLeagues(game Game) ([]League, error) {
 	leagues := new([]League)
@@ -9,12 +16,52 @@ func (c *Client) GetAllLeagues(game Game) ([]League, error) {
 	return *leagues, err
 }
 
+// Returns all leagues for the given game that were modified at or after the given time, along with the checkpoint for
+// the next call. See GetAllMatchesModifiedSince.
+func (c *Client) GetAllLeaguesModifiedSince(game Game, since time.Time) ([]League, time.Time, error) {
+	leagues := new([]League)
+	checkpoint, err := c.Request(game, "leagues").PageSize(100).GetAllModifiedSince(since, leagues)
+	return *leagues, checkpoint, err
+}
+
+// Returns all leagues for the given game whose name contains the given value.
+func (c *Client) SearchLeagues(game Game, name string) ([]League, error) {
+	leagues := new([]League)
+	_, err := c.Request(game, "leagues").Search("name", name).PageSize(100).GetAll(leagues)
+	return *leagues, err
+}
+
+// Returns all upcoming matches of the league with the given ID or slug.
+func (c *Client) GetLeagueUpcomingMatches(idOrSlug string) ([]Match, error) {
+	matches := new([]Match)
+	_, err := c.RequestAllGames(resourcePath("leagues", idOrSlug) + "/matches/upcoming").PageSize(100).GetAll(matches)
+	return *matches, err
+}
+
+// Returns all series of the league with the given ID or slug.
+func (c *Client) GetLeagueSeries(idOrSlug string) ([]Series, error) {
+	series := new([]Series)
+	_, err := c.RequestAllGames(resourcePath("leagues", idOrSlug) + "/series").PageSize(100).GetAll(series)
+	return *series, err
+}
+
+// Returns all tournaments of the league with the given ID or slug.
+func (c *Client) GetLeagueTournaments(idOrSlug string) ([]Tournament, error) {
+	tournaments := new([]Tournament)
+	_, err := c.RequestAllGames(resourcePath("leagues", idOrSlug) + "/tournaments").PageSize(100).GetAll(tournaments)
+	return *tournaments, err
+}
+
 // League represents a logical group of series, which are events that belong to a league.
 //
 // More information: https://developers.pandascore.co/doc/#section/Introduction/Events-hierarchy
 type League struct {
-	ID       int       `json:"id"`
-	Name     string    `json:"name"`
-	Modified time.Time `json:"modified_at"`
-	URL      string    `json:"url"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	ImageURL  string    `json:"image_url"`
+	Modified  time.Time `json:"modified_at"`
+	URL       string    `json:"url"`
+	Series    []Series  `json:"series"`
+	Videogame Videogame `json:"videogame"`
 }
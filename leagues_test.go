@@ -24,13 +24,110 @@ func TestGetLeagues(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.IsType(t, []League{}, result)
 	assert.Len(t, result, 50)
-	assert.Equal(t,
-		League{
-			ID:       4351,
-			Name:     "HIPFIRED CUP",
-			Modified: time.Date(2020, time.March, 26, 10, 5, 6, 0, time.UTC),
-			URL:      "https://hipfired.media/hipfired-cup/",
-		},
-		result[0],
-	)
+	assert.Equal(t, 4351, result[0].ID)
+	assert.Equal(t, "HIPFIRED CUP", result[0].Name)
+	assert.Equal(t, "cs-go-hipfired-cup", result[0].Slug)
+	assert.Equal(t, "https://cdn.pandascore.co/images/league/image/4351/600px-Hipfiredcup1.png", result[0].ImageURL)
+	assert.Equal(t, time.Date(2020, time.March, 26, 10, 5, 6, 0, time.UTC), result[0].Modified)
+	assert.Equal(t, "https://hipfired.media/hipfired-cup/", result[0].URL)
+	assert.Equal(t, "CS:GO", result[0].Videogame.Name)
+	assert.Len(t, result[0].Series, 1)
+	assert.Equal(t, "cs-go-hipfired-cup-2020", result[0].Series[0].Slug)
+}
+
+func TestClient_GetLeague(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/leagues/cs-go-esl").
+		Reply(http.StatusOK).
+		File("testdata/csgo-league.json")
+
+	client := New()
+	result, err := client.GetLeague("cs-go-esl")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4158, result.ID)
+	assert.Equal(t, "ESL", result.Name)
+}
+
+func TestClient_SearchLeagues(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		MatchParam("search[name]", "ESL").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json")
+
+	client := New()
+	result, err := client.SearchLeagues(CSGO, "ESL")
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "cs-go-esl", result[0].Slug)
+	assert.Greater(t, len(result[0].Series), 1)
+}
+
+func TestClient_GetLeagueUpcomingMatches(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/leagues/4158/matches/upcoming").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-upcoming.json")
+
+	client := New()
+	result, err := client.GetLeagueUpcomingMatches("4158")
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+}
+
+func TestClient_GetLeagueSeries(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/leagues/cs-go-esl/series").
+		Reply(http.StatusOK).
+		File("testdata/csgo-series-running.json")
+
+	client := New()
+	result, err := client.GetLeagueSeries("cs-go-esl")
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+}
+
+func TestClient_GetLeagueTournaments(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/leagues/4158/tournaments").
+		Reply(http.StatusOK).
+		File("testdata/csgo-tournaments.json")
+
+	client := New()
+	result, err := client.GetLeagueTournaments("4158")
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, "ESL", result[0].League.Name)
+}
+
+func TestClient_GetAllLeaguesModifiedSince(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		MatchParam("range[modified_at]", "2020-03-01T00:00:00Z,9999-12-31T23:59:59Z").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues.json")
+
+	client := New()
+	result, checkpoint, err := client.GetAllLeaguesModifiedSince(CSGO, time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Len(t, result, 50)
+	assert.Equal(t, time.Date(2020, time.March, 26, 10, 5, 6, 0, time.UTC), checkpoint)
 }
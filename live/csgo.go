@@ -0,0 +1,124 @@
+package live
+
+import (
+	"encoding/json"
+)
+
+const (
+	CounterTerrorist CSGOSide = "ct"
+	Terrorist        CSGOSide = "t"
+)
+
+// CSGOSide is the side a CS:GO team plays on in a round.
+type CSGOSide string
+
+// CSGOFrame represents the state of a CS:GO game (a single map) at a single point in time.
+type CSGOFrame struct {
+	Frame
+	Round             int      `json:"round"`
+	Finished          bool     `json:"finished"`
+	Map               string   `json:"map"`
+	CounterTerrorists CSGOTeam `json:"counter_terrorists"`
+	Terrorists        CSGOTeam `json:"terrorists"`
+}
+
+// Returns the team playing on the given side.
+func (f *CSGOFrame) Team(side CSGOSide) CSGOTeam {
+	if side == Terrorist {
+		return f.Terrorists
+	}
+	return f.CounterTerrorists
+}
+
+// CSGOTeam represents a team and its players in a CS:GO frame.
+type CSGOTeam struct {
+	ID      int          `json:"id"`
+	Name    string       `json:"name"`
+	Side    CSGOSide     `json:"side"`
+	Score   int          `json:"round_score"`
+	Players []CSGOPlayer `json:"players"`
+}
+
+// CSGOPlayer represents the statistics of a single player in a CS:GO frame.
+type CSGOPlayer struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Kills   int    `json:"kills"`
+	Deaths  int    `json:"deaths"`
+	Assists int    `json:"assists"`
+	Health  int    `json:"hp"`
+	Armor   int    `json:"armor"`
+	Money   int    `json:"money"`
+	Alive   bool   `json:"is_alive"`
+}
+
+// CSGOKillEvent is sent when a player is killed.
+type CSGOKillEvent struct {
+	Event
+	Round    int             `json:"round"`
+	Headshot bool            `json:"headshot"`
+	Weapon   string          `json:"weapon"`
+	Killer   CSGOEventPlayer `json:"killer"`
+	Killed   CSGOEventPlayer `json:"killed"`
+}
+
+// CSGOEventPlayer refers to a player involved in a CS:GO event.
+type CSGOEventPlayer struct {
+	ID   int      `json:"id"`
+	Name string   `json:"name"`
+	Side CSGOSide `json:"side"`
+}
+
+// CSGORoundEndEvent is sent when a round is over.
+type CSGORoundEndEvent struct {
+	Event
+	Round                  int      `json:"round"`
+	WinnerSide             CSGOSide `json:"winner_side"`
+	WinnerID               int      `json:"winner_id"`
+	Reason                 string   `json:"reason"`
+	CounterTerroristsScore int      `json:"counter_terrorists_score"`
+	TerroristsScore        int      `json:"terrorists_score"`
+}
+
+var csgoEventDecoders = map[string]eventDecoder{
+	"kill": func(event Event) (interface{}, error) {
+		kill := new(CSGOKillEvent)
+		err := json.Unmarshal(event.Payload, kill)
+		kill.Event = event
+		return kill, err
+	},
+	"round_end": func(event Event) (interface{}, error) {
+		roundEnd := new(CSGORoundEndEvent)
+		err := json.Unmarshal(event.Payload, roundEnd)
+		roundEnd.Event = event
+		return roundEnd, err
+	},
+}
+
+// A CS:GO frame as PandaScore sends it.
+type csgoFrameWire struct {
+	Game gameWire `json:"game"`
+	Map  struct {
+		Name string `json:"name"`
+	} `json:"map"`
+	CounterTerrorists CSGOTeam `json:"counter_terrorists"`
+	Terrorists        CSGOTeam `json:"terrorists"`
+}
+
+func decodeCSGOFrame(frame Frame) (interface{}, error) {
+	wire := new(csgoFrameWire)
+	if err := json.Unmarshal(frame.Raw, wire); err != nil {
+		return nil, err
+	}
+	wire.CounterTerrorists.Side = CounterTerrorist
+	wire.Terrorists.Side = Terrorist
+
+	return &CSGOFrame{
+		Frame:             frame,
+		Round:             wire.Game.Round,
+		Finished:          wire.Game.Finished,
+		Map:               wire.Map.Name,
+		CounterTerrorists: wire.CounterTerrorists,
+		Terrorists:        wire.Terrorists,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package live
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+func readTestFrame(t *testing.T, name string) Frame {
+	frame, err := decodeFrame([]byte(readTestdata(name)))
+	assert.Nil(t, err)
+	return *frame
+}
+
+func readTestEvent(t *testing.T, name string) Event {
+	event, err := decodeEvent([]byte(readTestdata(name)))
+	assert.Nil(t, err)
+	return *event
+}
+
+func TestDecodeFrame_csgo(t *testing.T) {
+	result, err := DecodeFrame(pandascore.CSGO, readTestFrame(t, "csgo-frame.json"))
+
+	assert.Nil(t, err)
+	assert.IsType(t, &CSGOFrame{}, result)
+
+	frame := result.(*CSGOFrame)
+	assert.Equal(t, 559177, frame.MatchID)
+	assert.Equal(t, 12, frame.Round)
+	assert.False(t, frame.Finished)
+	assert.Equal(t, "Mirage", frame.Map)
+
+	assert.Equal(t, "FaZe", frame.CounterTerrorists.Name)
+	assert.Equal(t, CounterTerrorist, frame.CounterTerrorists.Side)
+	assert.Equal(t, 7, frame.CounterTerrorists.Score)
+	assert.Equal(t, "North", frame.Team(Terrorist).Name)
+	assert.Equal(t, Terrorist, frame.Terrorists.Side)
+	assert.Equal(t, 4, frame.Terrorists.Score)
+
+	assert.Len(t, frame.CounterTerrorists.Players, 2)
+	assert.Equal(t,
+		CSGOPlayer{ID: 1794, Name: "NiKo", Kills: 14, Deaths: 8, Assists: 3, Health: 100, Armor: 100, Money: 4750, Alive: true},
+		frame.CounterTerrorists.Players[0],
+	)
+	assert.False(t, frame.CounterTerrorists.Players[1].Alive)
+}
+
+func TestDecodeEvent_csgoKill(t *testing.T) {
+	result, err := DecodeEvent(pandascore.CSGO, readTestEvent(t, "csgo-event-kill.json"))
+
+	assert.Nil(t, err)
+	assert.IsType(t, &CSGOKillEvent{}, result)
+
+	kill := result.(*CSGOKillEvent)
+	assert.Equal(t, "kill", kill.Type)
+	assert.Equal(t, 12, kill.Round)
+	assert.True(t, kill.Headshot)
+	assert.Equal(t, "ak47", kill.Weapon)
+	assert.Equal(t, CSGOEventPlayer{ID: 1794, Name: "NiKo", Side: CounterTerrorist}, kill.Killer)
+	assert.Equal(t, CSGOEventPlayer{ID: 1812, Name: "aizy", Side: Terrorist}, kill.Killed)
+}
+
+func TestDecodeEvent_csgoRoundEnd(t *testing.T) {
+	result, err := DecodeEvent(pandascore.CSGO, readTestEvent(t, "csgo-event-round-end.json"))
+
+	assert.Nil(t, err)
+	assert.IsType(t, &CSGORoundEndEvent{}, result)
+
+	roundEnd := result.(*CSGORoundEndEvent)
+	assert.Equal(t, 12, roundEnd.Round)
+	assert.Equal(t, CounterTerrorist, roundEnd.WinnerSide)
+	assert.Equal(t, 3212, roundEnd.WinnerID)
+	assert.Equal(t, "eliminated", roundEnd.Reason)
+	assert.Equal(t, 8, roundEnd.CounterTerroristsScore)
+	assert.Equal(t, 4, roundEnd.TerroristsScore)
+}
+
+func TestDecodeEvent_csgoUnknownType(t *testing.T) {
+	event := readTestEvent(t, "csgo-event-bomb-planted.json")
+
+	result, err := DecodeEvent(pandascore.CSGO, event)
+
+	assert.Nil(t, err)
+	assert.Equal(t, event, result, "Expected unknown events to be returned as they are")
+}
+
+func TestCSGOFrame_marshal(t *testing.T) {
+	frame, _ := DecodeFrame(pandascore.CSGO, readTestFrame(t, "csgo-frame.json"))
+
+	data, err := json.Marshal(frame)
+	assert.Nil(t, err)
+
+	result := new(CSGOFrame)
+	assert.Nil(t, json.Unmarshal(data, result))
+	assert.Equal(t, 12, result.Round)
+	assert.Equal(t, "Mirage", result.Map)
+	assert.Equal(t, Terrorist, result.Terrorists.Side)
+	assert.Equal(t, frame.(*CSGOFrame).CounterTerrorists, result.CounterTerrorists)
+}
@@ -0,0 +1,55 @@
+package live
+
+import (
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// Decodes a frame into a game specific type, eg. *CSGOFrame.
+type frameDecoder func(frame Frame) (interface{}, error)
+
+// Decodes an event of a specific type into a game specific type, eg. *CSGOKillEvent.
+type eventDecoder func(event Event) (interface{}, error)
+
+var (
+	frameDecoders = map[pandascore.Game]frameDecoder{
+		pandascore.CSGO:  decodeCSGOFrame,
+		pandascore.LoL:   decodeLoLFrame,
+		pandascore.Dota2: decodeDota2Frame,
+	}
+
+	eventDecoders = map[pandascore.Game]map[string]eventDecoder{
+		pandascore.CSGO: csgoEventDecoders,
+	}
+)
+
+// Decode the given frame into the frame type of the given game (eg. *CSGOFrame for pandascore.CSGO). Frames of games
+// without a specific frame type are returned as they are.
+func DecodeFrame(game pandascore.Game, frame Frame) (interface{}, error) {
+	decode, ok := frameDecoders[game]
+	if !ok {
+		return frame, nil
+	}
+	return decode(frame)
+}
+
+// Decode the given event into the event type of the given game (eg. *CSGOKillEvent for a CS:GO kill event). Events of
+// unknown types are returned as they are, with the payload as raw JSON.
+func DecodeEvent(game pandascore.Game, event Event) (interface{}, error) {
+	decode, ok := eventDecoders[game][event.Type]
+	if !ok {
+		return event, nil
+	}
+	return decode(event)
+}
+
+// Item refers to anything identified by an ID and a name in a frame, like an item, a champion or a hero.
+type Item struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// State of the game shared by the frames of all video games, as PandaScore sends it.
+type gameWire struct {
+	Finished bool `json:"finished"`
+	Round    int  `json:"round"`
+}
@@ -0,0 +1,26 @@
+package live
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+func TestDecodeFrame_unknownGame(t *testing.T) {
+	frame := readTestFrame(t, "frame.json")
+
+	result, err := DecodeFrame(pandascore.Game("unknown"), frame)
+
+	assert.Nil(t, err)
+	assert.Equal(t, frame, result)
+}
+
+func TestDecodeEvent_unknownGame(t *testing.T) {
+	event := readTestEvent(t, "event.json")
+
+	result, err := DecodeEvent(pandascore.Game("unknown"), event)
+
+	assert.Nil(t, err)
+	assert.Equal(t, event, result)
+}
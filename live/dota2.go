@@ -0,0 +1,57 @@
+package live
+
+import (
+	"encoding/json"
+)
+
+// Dota2Frame represents the state of a Dota 2 game at a single point in time.
+type Dota2Frame struct {
+	Frame
+	Finished bool      `json:"finished"`
+	Radiant  Dota2Team `json:"radiant"`
+	Dire     Dota2Team `json:"dire"`
+}
+
+// Dota2Team represents a team and its players in a Dota 2 frame. Towers and Barracks are the number of buildings the
+// team has left standing.
+type Dota2Team struct {
+	ID          int           `json:"id"`
+	Name        string        `json:"name"`
+	Kills       int           `json:"score"`
+	Gold        int           `json:"gold"`
+	Towers      int           `json:"towers"`
+	Barracks    int           `json:"barracks"`
+	RoshanKills int           `json:"roshan_kills"`
+	Players     []Dota2Player `json:"players"`
+}
+
+// Dota2Player represents the statistics of a single player in a Dota 2 frame.
+type Dota2Player struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Hero     Item   `json:"hero"`
+	Level    int    `json:"level"`
+	Kills    int    `json:"kills"`
+	Deaths   int    `json:"deaths"`
+	Assists  int    `json:"assists"`
+	LastHits int    `json:"last_hits"`
+	Denies   int    `json:"denies"`
+	Gold     int    `json:"gold"`
+	NetWorth int    `json:"net_worth"`
+	Items    []Item `json:"items"`
+}
+
+// A Dota 2 frame as PandaScore sends it.
+type dota2FrameWire struct {
+	Game    gameWire  `json:"game"`
+	Radiant Dota2Team `json:"radiant"`
+	Dire    Dota2Team `json:"dire"`
+}
+
+func decodeDota2Frame(frame Frame) (interface{}, error) {
+	wire := new(dota2FrameWire)
+	if err := json.Unmarshal(frame.Raw, wire); err != nil {
+		return nil, err
+	}
+	return &Dota2Frame{Frame: frame, Finished: wire.Game.Finished, Radiant: wire.Radiant, Dire: wire.Dire}, nil
+}
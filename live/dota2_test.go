@@ -0,0 +1,56 @@
+package live
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+func TestDecodeFrame_dota2(t *testing.T) {
+	result, err := DecodeFrame(pandascore.Dota2, readTestFrame(t, "dota2-frame.json"))
+
+	assert.Nil(t, err)
+	assert.IsType(t, &Dota2Frame{}, result)
+
+	frame := result.(*Dota2Frame)
+	assert.Equal(t, 559402, frame.MatchID)
+	assert.False(t, frame.Finished)
+
+	assert.Equal(t, "Team Secret", frame.Radiant.Name)
+	assert.Equal(t, 23, frame.Radiant.Kills)
+	assert.Equal(t, 48210, frame.Radiant.Gold)
+	assert.Equal(t, 9, frame.Radiant.Towers)
+	assert.Equal(t, 6, frame.Radiant.Barracks)
+	assert.Equal(t, 1, frame.Radiant.RoshanKills)
+	assert.Equal(t, "OG", frame.Dire.Name)
+	assert.Equal(t, 4, frame.Dire.Barracks)
+
+	assert.Len(t, frame.Radiant.Players, 1)
+	assert.Equal(t,
+		Dota2Player{
+			ID:       3015,
+			Name:     "Nisha",
+			Hero:     Item{ID: 74, Name: "Invoker"},
+			Level:    19,
+			Kills:    9,
+			Deaths:   1,
+			Assists:  7,
+			LastHits: 312,
+			Denies:   14,
+			Gold:     2140,
+			NetWorth: 18450,
+			Items:    []Item{{ID: 108, Name: "Aghanim's Scepter"}},
+		},
+		frame.Radiant.Players[0],
+	)
+}
+
+func TestDecodeEvent_dota2UnknownType(t *testing.T) {
+	event := readTestEvent(t, "event.json")
+
+	result, err := DecodeEvent(pandascore.Dota2, event)
+
+	assert.Nil(t, err)
+	assert.Equal(t, event, result)
+}
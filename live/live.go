@@ -0,0 +1,222 @@
+// Consume live PandaScore match data (frames and events) over WebSocket connections.
+//
+// Every match that supports live data has a WebSocket URL (see pandascore.MatchLive) which sends a frame with the
+// complete state of the game every few seconds. Events (kills, objectives, ...) are sent over a separate connection on
+// the same URL suffixed with /events.
+package live
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+const (
+	// Default time to wait before reconnecting after a connection failed for the first time
+	DefaultMinBackoff = time.Second
+
+	// Default maximum time to wait before reconnecting; the wait time doubles after every failed attempt up until this
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// Live client for a single PandaScore match.
+type Client struct {
+	url         string
+	accessToken string
+	dialer      *websocket.Dialer
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+// Construct a new live client for the given WebSocket URL, typically taken from pandascore.Match.Live.URL.
+//
+// Like the regular PandaScore client, the access token will be read from the environment variable defined in the
+// pandascore.AccessTokenEnvironmentVariable constant by default.
+func New(url string) *Client {
+	return &Client{
+		url:         url,
+		accessToken: os.Getenv(pandascore.AccessTokenEnvironmentVariable),
+		dialer:      websocket.DefaultDialer,
+		minBackoff:  DefaultMinBackoff,
+		maxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// Construct a new live client for the given match. Returns false if the match doesn't support live data.
+func ForMatch(match pandascore.Match) (*Client, bool) {
+	if !match.Live.Supported || len(match.Live.URL) == 0 {
+		return nil, false
+	}
+	return New(match.Live.URL), true
+}
+
+// Sets this client's PandaScore access token to the given value.
+func (c *Client) AccessToken(accessToken string) *Client {
+	c.accessToken = accessToken
+	return c
+}
+
+// Sets the minimum and maximum time to wait before reconnecting after a connection is lost or can't be established.
+func (c *Client) Backoff(min time.Duration, max time.Duration) *Client {
+	if min > 0 {
+		c.minBackoff = min
+	}
+	if max >= c.minBackoff {
+		c.maxBackoff = max
+	}
+	return c
+}
+
+// Stream of live data for a single match. All channels are closed once the stream is stopped.
+type Stream struct {
+	// Frames with the complete state of the game, as they are received
+	Frames <-chan Frame
+
+	// Events that happened in the game, as they are received
+	Events <-chan Event
+
+	// Connection and decoding errors. Errors are dropped if they aren't read, so this channel can be safely ignored.
+	Errors <-chan error
+
+	// Closed once all channels above are closed
+	done chan struct{}
+}
+
+// Connect to the frames and events of the match and keep the connections open until the given context is done. Lost
+// connections are reestablished automatically, waiting longer after every failed attempt.
+func (c *Client) Stream(ctx context.Context) *Stream {
+	frames := make(chan Frame)
+	events := make(chan Event)
+	errors := make(chan error, 16)
+
+	framesDone := make(chan struct{})
+	eventsDone := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(framesDone)
+		c.run(ctx, c.url, func(message []byte) error {
+			frame, err := decodeFrame(message)
+			if err != nil || frame == nil {
+				return err
+			}
+			select {
+			case frames <- *frame:
+			case <-ctx.Done():
+			}
+			return nil
+		}, errors)
+	}()
+
+	go func() {
+		defer close(eventsDone)
+		c.run(ctx, c.url+"/events", func(message []byte) error {
+			event, err := decodeEvent(message)
+			if err != nil || event == nil {
+				return err
+			}
+			select {
+			case events <- *event:
+			case <-ctx.Done():
+			}
+			return nil
+		}, errors)
+	}()
+
+	go func() {
+		<-framesDone
+		<-eventsDone
+		close(frames)
+		close(events)
+		close(errors)
+		close(done)
+	}()
+
+	return &Stream{Frames: frames, Events: events, Errors: errors, done: done}
+}
+
+// Keep a connection to the given URL open until the context is done, passing every message to the given handler.
+func (c *Client) run(ctx context.Context, rawURL string, handle func([]byte) error, errors chan<- error) {
+	backoff := c.minBackoff
+
+	for ctx.Err() == nil {
+		connection, err := c.connect(ctx, rawURL)
+		if err == nil {
+			backoff = c.minBackoff
+			err = c.read(ctx, connection, handle, errors)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("PandaScore live connection to %s lost, reconnecting in %s: %s", rawURL, backoff, err)
+		reportError(errors, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+func (c *Client) connect(ctx context.Context, rawURL string) (*websocket.Conn, error) {
+	connectURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.accessToken) > 0 {
+		query := connectURL.Query()
+		query.Set("token", c.accessToken)
+		connectURL.RawQuery = query.Encode()
+	} else {
+		log.Print("⚠ warning: PandaScore access token hasn't been set, live connections may fail")
+	}
+
+	connection, _, err := c.dialer.DialContext(ctx, connectURL.String(), nil)
+	return connection, err
+}
+
+// Read messages from the connection until it fails or the context is done. Messages that can't be decoded are
+// reported but don't close the connection.
+func (c *Client) read(ctx context.Context, connection *websocket.Conn, handle func([]byte) error, errors chan<- error) error {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = connection.Close()
+		case <-stop:
+			_ = connection.Close()
+		}
+	}()
+
+	for {
+		_, message, err := connection.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if err := handle(message); err != nil {
+			reportError(errors, err)
+		}
+	}
+}
+
+func reportError(errors chan<- error, err error) {
+	select {
+	case errors <- err:
+	default:
+	}
+}
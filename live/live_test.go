@@ -0,0 +1,166 @@
+package live
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// Local stand-in for the PandaScore live server, which sends the given messages to every connection on a path and
+// closes the connection afterwards if closeAfterSending is set.
+type fakeLiveServer struct {
+	*httptest.Server
+	messages          map[string][]string
+	closeAfterSending bool
+
+	mutex       sync.Mutex
+	connections map[string]int
+	tokens      []string
+}
+
+func newFakeLiveServer(messages map[string][]string, closeAfterSending bool) *fakeLiveServer {
+	server := &fakeLiveServer{messages: messages, closeAfterSending: closeAfterSending, connections: make(map[string]int)}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	return server
+}
+
+func (s *fakeLiveServer) handle(w http.ResponseWriter, r *http.Request) {
+	connection, err := new(websocket.Upgrader).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer connection.Close()
+
+	s.mutex.Lock()
+	s.connections[r.URL.Path]++
+	s.tokens = append(s.tokens, r.URL.Query().Get("token"))
+	s.mutex.Unlock()
+
+	for _, message := range s.messages[r.URL.Path] {
+		if err := connection.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+			return
+		}
+	}
+
+	if !s.closeAfterSending {
+		for {
+			if _, _, err := connection.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *fakeLiveServer) connectionsTo(path string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.connections[path]
+}
+
+func (s *fakeLiveServer) matchURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http") + "/matches/559177"
+}
+
+func readTestdata(name string) string {
+	content, _ := ioutil.ReadFile("testdata/" + name)
+	return string(content)
+}
+
+func TestNew(t *testing.T) {
+	result := New("wss://live.pandascore.co/matches/559177")
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "wss://live.pandascore.co/matches/559177", result.url)
+	assert.Equal(t, DefaultMinBackoff, result.minBackoff)
+	assert.Equal(t, DefaultMaxBackoff, result.maxBackoff)
+}
+
+func TestForMatch(t *testing.T) {
+	result, ok := ForMatch(pandascore.Match{Live: pandascore.MatchLive{Supported: true, URL: "wss://live.pandascore.co/matches/1"}})
+	assert.True(t, ok)
+	assert.Equal(t, "wss://live.pandascore.co/matches/1", result.url)
+
+	_, ok = ForMatch(pandascore.Match{Live: pandascore.MatchLive{Supported: false, URL: "wss://live.pandascore.co/matches/1"}})
+	assert.False(t, ok, "Expected no client for a match without live support")
+}
+
+func TestClient_Backoff(t *testing.T) {
+	result := New("").Backoff(time.Millisecond, time.Second)
+	assert.Equal(t, time.Millisecond, result.minBackoff)
+	assert.Equal(t, time.Second, result.maxBackoff)
+
+	result.Backoff(0, time.Microsecond)
+	assert.Equal(t, time.Millisecond, result.minBackoff, "Expected invalid minimum backoff to be ignored")
+	assert.Equal(t, time.Second, result.maxBackoff, "Expected maximum backoff below the minimum to be ignored")
+}
+
+func TestClient_Stream(t *testing.T) {
+	server := newFakeLiveServer(map[string][]string{
+		"/matches/559177":        {readTestdata("hello.json"), readTestdata("frame.json")},
+		"/matches/559177/events": {readTestdata("hello.json"), readTestdata("event.json")},
+	}, false)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := New(server.matchURL()).AccessToken("test_access_token").Stream(ctx)
+
+	frame := <-stream.Frames
+	assert.Equal(t, 559177, frame.MatchID)
+	assert.Equal(t, 18413, frame.GameID)
+
+	event := <-stream.Events
+	assert.Equal(t, "kill", event.Type)
+
+	assert.Equal(t, []string{"test_access_token", "test_access_token"}, server.tokens)
+
+	cancel()
+	_, framesOpen := <-stream.Frames
+	_, eventsOpen := <-stream.Events
+	assert.False(t, framesOpen, "Expected frames channel to be closed after the context is done")
+	assert.False(t, eventsOpen, "Expected events channel to be closed after the context is done")
+}
+
+func TestClient_Stream_reconnects(t *testing.T) {
+	server := newFakeLiveServer(map[string][]string{
+		"/matches/559177": {readTestdata("frame.json")},
+	}, true)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := New(server.matchURL()).Backoff(time.Millisecond, 10*time.Millisecond).Stream(ctx)
+
+	<-stream.Frames
+	<-stream.Frames
+	<-stream.Frames
+
+	assert.GreaterOrEqual(t, server.connectionsTo("/matches/559177"), 3)
+}
+
+func TestClient_Stream_reportsErrors(t *testing.T) {
+	server := newFakeLiveServer(map[string][]string{
+		"/matches/559177": {"not json", readTestdata("frame.json")},
+	}, false)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := New(server.matchURL()).Stream(ctx)
+
+	frame := <-stream.Frames
+	assert.Equal(t, 559177, frame.MatchID, "Expected invalid messages not to close the connection")
+	assert.NotNil(t, <-stream.Errors)
+}
@@ -0,0 +1,58 @@
+package live
+
+import (
+	"encoding/json"
+)
+
+// LoLFrame represents the state of a League of Legends game at a single point in time.
+type LoLFrame struct {
+	Frame
+	Finished bool    `json:"finished"`
+	Blue     LoLTeam `json:"blue"`
+	Red      LoLTeam `json:"red"`
+}
+
+// LoLTeam represents a team and its players in a League of Legends frame.
+type LoLTeam struct {
+	ID         int                  `json:"id"`
+	Name       string               `json:"name"`
+	Acronym    string               `json:"acronym"`
+	Gold       int                  `json:"gold"`
+	Kills      int                  `json:"kills"`
+	Towers     int                  `json:"towers"`
+	Inhibitors int                  `json:"inhibitors"`
+	Dragons    int                  `json:"drakes"`
+	Barons     int                  `json:"nashors"`
+	Herald     bool                 `json:"herald"`
+	Players    map[string]LoLPlayer `json:"players"`
+}
+
+// LoLPlayer represents the statistics of a single player in a League of Legends frame. Players are keyed by their role
+// (top, jun, mid, adc or sup) in their team.
+type LoLPlayer struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Champion Item   `json:"champion"`
+	Level    int    `json:"level"`
+	Kills    int    `json:"kills"`
+	Deaths   int    `json:"deaths"`
+	Assists  int    `json:"assists"`
+	CS       int    `json:"cs"`
+	Health   int    `json:"hp"`
+	Items    []Item `json:"items"`
+}
+
+// A League of Legends frame as PandaScore sends it.
+type lolFrameWire struct {
+	Game gameWire `json:"game"`
+	Blue LoLTeam  `json:"blue"`
+	Red  LoLTeam  `json:"red"`
+}
+
+func decodeLoLFrame(frame Frame) (interface{}, error) {
+	wire := new(lolFrameWire)
+	if err := json.Unmarshal(frame.Raw, wire); err != nil {
+		return nil, err
+	}
+	return &LoLFrame{Frame: frame, Finished: wire.Game.Finished, Blue: wire.Blue, Red: wire.Red}, nil
+}
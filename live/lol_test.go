@@ -0,0 +1,48 @@
+package live
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+func TestDecodeFrame_lol(t *testing.T) {
+	result, err := DecodeFrame(pandascore.LoL, readTestFrame(t, "lol-frame.json"))
+
+	assert.Nil(t, err)
+	assert.IsType(t, &LoLFrame{}, result)
+
+	frame := result.(*LoLFrame)
+	assert.Equal(t, 559310, frame.MatchID)
+	assert.Equal(t, 214005, frame.GameID)
+	assert.False(t, frame.Finished)
+
+	assert.Equal(t, "G2 Esports", frame.Blue.Name)
+	assert.Equal(t, 41250, frame.Blue.Gold)
+	assert.Equal(t, 11, frame.Blue.Kills)
+	assert.Equal(t, 4, frame.Blue.Towers)
+	assert.Equal(t, 0, frame.Blue.Inhibitors)
+	assert.Equal(t, 2, frame.Blue.Dragons)
+	assert.Equal(t, 1, frame.Blue.Barons)
+	assert.True(t, frame.Blue.Herald)
+	assert.Equal(t, "Fnatic", frame.Red.Name)
+	assert.Empty(t, frame.Red.Players)
+
+	assert.Len(t, frame.Blue.Players, 2)
+	top := frame.Blue.Players["top"]
+	assert.Equal(t, "Wunder", top.Name)
+	assert.Equal(t, Item{ID: 54, Name: "Ornn"}, top.Champion)
+	assert.Equal(t, 188, top.CS)
+	assert.Equal(t, []Item{{ID: 3068, Name: "Sunfire Cape"}, {ID: 3047, Name: "Ninja Tabi"}}, top.Items)
+}
+
+func TestDecodeEvent_lolUnknownType(t *testing.T) {
+	event := readTestEvent(t, "lol-event-kill.json")
+
+	result, err := DecodeEvent(pandascore.LoL, event)
+
+	assert.Nil(t, err)
+	assert.Equal(t, event, result, "Expected events without a specific type to be returned with their raw payload")
+	assert.Contains(t, string(result.(Event).Payload), "Caps")
+}
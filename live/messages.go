@@ -0,0 +1,83 @@
+package live
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Type of the message PandaScore sends right after a connection is established; it carries no data.
+const helloMessageType = "hello"
+
+// Frame represents the complete state of a game at a single point in time.
+//
+// Raw contains the complete frame as received from PandaScore, which differs per video game. Use DecodeFrame to decode
+// it into a game specific frame.
+type Frame struct {
+	MatchID   int             `json:"match_id"`
+	GameID    int             `json:"game_id"`
+	Timestamp time.Time       `json:"timestamp"`
+	Raw       json.RawMessage `json:"raw"`
+}
+
+// Event represents something that happened in a game, like a kill or a destroyed objective.
+//
+// Payload contains the event specific data as received from PandaScore, which differs per type of event. Use
+// DecodeEvent to decode it into a game specific event.
+type Event struct {
+	Type      string          `json:"type"`
+	MatchID   int             `json:"match_id"`
+	GameID    int             `json:"game_id"`
+	Timestamp time.Time       `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// Fields shared by all frames and events as PandaScore sends them.
+type envelope struct {
+	Type string `json:"type"`
+	Game struct {
+		ID int `json:"id"`
+	} `json:"game"`
+	Match struct {
+		ID int `json:"id"`
+	} `json:"match"`
+	CurrentTimestamp int64           `json:"current_timestamp"`
+	Timestamp        int64           `json:"ts"`
+	Payload          json.RawMessage `json:"payload"`
+}
+
+// Decode a message received on the frames connection. Returns nil if the message isn't a frame.
+func decodeFrame(message []byte) (*Frame, error) {
+	e := new(envelope)
+	if err := json.Unmarshal(message, e); err != nil {
+		return nil, err
+	}
+	if e.Type == helloMessageType {
+		return nil, nil
+	}
+
+	return &Frame{
+		MatchID:   e.Match.ID,
+		GameID:    e.Game.ID,
+		Timestamp: time.Unix(e.CurrentTimestamp, 0).UTC(),
+		Raw:       json.RawMessage(message),
+	}, nil
+}
+
+// Decode a message received on the events connection. Returns nil if the message isn't an event.
+func decodeEvent(message []byte) (*Event, error) {
+	e := new(envelope)
+	if err := json.Unmarshal(message, e); err != nil {
+		return nil, err
+	}
+	if e.Type == helloMessageType || len(e.Type) == 0 {
+		return nil, nil
+	}
+
+	return &Event{
+		Type:      e.Type,
+		MatchID:   e.Match.ID,
+		GameID:    e.Game.ID,
+		Timestamp: time.Unix(e.Timestamp, 0).UTC(),
+		Payload:   e.Payload,
+	}, nil
+}
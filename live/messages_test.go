@@ -0,0 +1,90 @@
+package live
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_decodeFrame(t *testing.T) {
+	message, _ := ioutil.ReadFile("testdata/frame.json")
+
+	result, err := decodeFrame(message)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, 559177, result.MatchID)
+	assert.Equal(t, 18413, result.GameID)
+	assert.Equal(t, time.Date(2020, time.April, 23, 13, 2, 11, 0, time.UTC), result.Timestamp)
+	assert.Equal(t, json.RawMessage(message), result.Raw)
+}
+
+func Test_decodeFrame_hello(t *testing.T) {
+	message, _ := ioutil.ReadFile("testdata/hello.json")
+
+	result, err := decodeFrame(message)
+
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_decodeFrame_invalidJSON(t *testing.T) {
+	_, err := decodeFrame([]byte("not json"))
+
+	assert.NotNil(t, err)
+}
+
+func Test_decodeEvent(t *testing.T) {
+	message, _ := ioutil.ReadFile("testdata/event.json")
+
+	result, err := decodeEvent(message)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "kill", result.Type)
+	assert.Equal(t, 559177, result.MatchID)
+	assert.Equal(t, 18413, result.GameID)
+	assert.Equal(t, time.Date(2020, time.April, 23, 13, 2, 15, 0, time.UTC), result.Timestamp)
+	assert.Contains(t, string(result.Payload), "NiKo")
+}
+
+func Test_decodeEvent_hello(t *testing.T) {
+	message, _ := ioutil.ReadFile("testdata/hello.json")
+
+	result, err := decodeEvent(message)
+
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestFrame_marshal(t *testing.T) {
+	message, _ := ioutil.ReadFile("testdata/frame.json")
+	frame, _ := decodeFrame(message)
+
+	data, err := json.Marshal(frame)
+	assert.Nil(t, err)
+
+	result := new(Frame)
+	assert.Nil(t, json.Unmarshal(data, result))
+	assert.Equal(t, frame.MatchID, result.MatchID)
+	assert.Equal(t, frame.GameID, result.GameID)
+	assert.True(t, frame.Timestamp.Equal(result.Timestamp))
+	assert.JSONEq(t, string(frame.Raw), string(result.Raw))
+}
+
+func TestEvent_marshal(t *testing.T) {
+	message, _ := ioutil.ReadFile("testdata/event.json")
+	event, _ := decodeEvent(message)
+
+	data, err := json.Marshal(event)
+	assert.Nil(t, err)
+
+	result := new(Event)
+	assert.Nil(t, json.Unmarshal(data, result))
+	assert.Equal(t, "kill", result.Type)
+	assert.Equal(t, event.MatchID, result.MatchID)
+	assert.JSONEq(t, string(event.Payload), string(result.Payload))
+}
@@ -0,0 +1,226 @@
+package live
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+const (
+	// Default time between two polls of the running and upcoming matches
+	DefaultPollInterval = time.Minute
+
+	// Default maximum number of matches streamed at the same time
+	DefaultMaxConnections = 10
+)
+
+// Supervisor streams every match that supports live data for a number of games. It polls the running and upcoming
+// matches, connects to each match once its live window opens and disconnects once the match is finished. Upcoming
+// matches without a live window are only connected to once they're running.
+type Supervisor struct {
+	client         *pandascore.Client
+	games          []pandascore.Game
+	accessToken    string
+	pollInterval   time.Duration
+	maxConnections int
+	connect        func(match pandascore.Match) (*Client, bool)
+}
+
+// MatchStream is the live stream of a single match started by the supervisor. The channels of the stream are closed
+// once the match is finished or the supervisor is stopped.
+type MatchStream struct {
+	*Stream
+	Game  pandascore.Game
+	Match pandascore.Match
+}
+
+// Construct a new supervisor that uses the given client to find matches of the given games.
+func NewSupervisor(client *pandascore.Client, games ...pandascore.Game) *Supervisor {
+	return &Supervisor{
+		client:         client,
+		games:          games,
+		pollInterval:   DefaultPollInterval,
+		maxConnections: DefaultMaxConnections,
+		connect:        ForMatch,
+	}
+}
+
+// Sets the PandaScore access token used for live connections. By default the access token is read from the
+// environment variable defined in the pandascore.AccessTokenEnvironmentVariable constant.
+func (s *Supervisor) AccessToken(accessToken string) *Supervisor {
+	s.accessToken = accessToken
+	return s
+}
+
+// Sets the time between two polls of the running and upcoming matches. The interval must be larger than 0.
+func (s *Supervisor) PollInterval(interval time.Duration) *Supervisor {
+	if interval > 0 {
+		s.pollInterval = interval
+	}
+	return s
+}
+
+// Sets the maximum number of matches that are streamed at the same time. Matches whose live window opens while the
+// maximum is reached are connected to as soon as another match is finished. The maximum must be larger than 0.
+func (s *Supervisor) MaxConnections(max int) *Supervisor {
+	if max > 0 {
+		s.maxConnections = max
+	}
+	return s
+}
+
+// Start supervising matches until the given context is done. A MatchStream is sent on the returned channel for every
+// match that is connected to; the channel is closed when the supervisor stops.
+func (s *Supervisor) Run(ctx context.Context) <-chan MatchStream {
+	streams := make(chan MatchStream)
+
+	go func() {
+		defer close(streams)
+
+		// Matches stay active until the channels of their stream are closed, so disconnecting matches still count
+		// towards the maximum number of connections
+		active := make(map[int]context.CancelFunc)
+		closed := make(chan int)
+		defer func() {
+			for _, cancel := range active {
+				cancel()
+			}
+		}()
+
+		var pending []supervisedMatch
+		nextPoll := time.Now()
+
+		for {
+			if !time.Now().Before(nextPoll) {
+				if matches, err := s.poll(ctx); err == nil {
+					pending = s.update(matches, active)
+				} else {
+					log.Printf("failed to poll PandaScore matches for live data: %s", err)
+				}
+				nextPoll = time.Now().Add(s.pollInterval)
+			}
+
+			wake := nextPoll
+			for len(pending) > 0 && len(active) < s.maxConnections {
+				if pending[0].match.Live.OpensAt.After(time.Now()) {
+					if pending[0].match.Live.OpensAt.Before(wake) {
+						wake = pending[0].match.Live.OpensAt
+					}
+					break
+				}
+
+				stream, ok := s.start(ctx, pending[0], active, closed)
+				pending = pending[1:]
+				if !ok {
+					continue
+				}
+
+				select {
+				case streams <- stream:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-time.After(time.Until(wake)):
+			case id := <-closed:
+				active[id]()
+				delete(active, id)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return streams
+}
+
+// A match together with the game it belongs to, because the video game of a match doesn't map to a Game directly.
+type supervisedMatch struct {
+	game  pandascore.Game
+	match pandascore.Match
+}
+
+// Returns all running and upcoming matches of the supervised games that support live data. Upcoming matches whose
+// live window isn't known yet are left out.
+func (s *Supervisor) poll(ctx context.Context) ([]supervisedMatch, error) {
+	var matches []supervisedMatch
+	for _, game := range s.games {
+		running := new([]pandascore.Match)
+		if _, err := s.client.Request(game, "matches/running").PageSize(100).Context(ctx).GetAll(running); err != nil {
+			return nil, err
+		}
+		upcoming := new([]pandascore.Match)
+		if _, err := s.client.Request(game, "matches/upcoming").PageSize(100).Context(ctx).GetAll(upcoming); err != nil {
+			return nil, err
+		}
+
+		for _, match := range *running {
+			if match.Live.Supported && !match.IsFinished() && match.Status != "canceled" {
+				matches = append(matches, supervisedMatch{game: game, match: match})
+			}
+		}
+		for _, match := range *upcoming {
+			if match.Live.Supported && !match.Live.OpensAt.IsZero() && match.Status != "canceled" {
+				matches = append(matches, supervisedMatch{game: game, match: match})
+			}
+		}
+	}
+	return matches, nil
+}
+
+// Disconnect from all active matches that are no longer running or upcoming and return the matches that aren't
+// connected to yet, ordered by the time their live window opens. Disconnected matches stay active until their stream
+// is closed.
+func (s *Supervisor) update(matches []supervisedMatch, active map[int]context.CancelFunc) []supervisedMatch {
+	current := make(map[int]bool)
+	var pending []supervisedMatch
+	for _, match := range matches {
+		if current[match.match.ID] {
+			continue
+		}
+		current[match.match.ID] = true
+		if _, connected := active[match.match.ID]; !connected {
+			pending = append(pending, match)
+		}
+	}
+
+	for id, cancel := range active {
+		if !current[id] {
+			cancel()
+		}
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].match.Live.OpensAt.Before(pending[j].match.Live.OpensAt)
+	})
+	return pending
+}
+
+// Connect to the given match and mark it active. The ID of the match is sent on closed once its stream is closed.
+func (s *Supervisor) start(ctx context.Context, match supervisedMatch, active map[int]context.CancelFunc,
+	closed chan<- int) (MatchStream, bool) {
+	client, ok := s.connect(match.match)
+	if !ok {
+		return MatchStream{}, false
+	}
+	if len(s.accessToken) > 0 {
+		client.AccessToken(s.accessToken)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	active[match.match.ID] = cancel
+	stream := client.Stream(streamCtx)
+	go func() {
+		<-stream.done
+		select {
+		case closed <- match.match.ID:
+		case <-ctx.Done():
+		}
+	}()
+	return MatchStream{Stream: stream, Game: match.game, Match: match.match}, true
+}
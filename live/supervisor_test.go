@@ -0,0 +1,217 @@
+package live
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// Returns a supervisor that connects to the given fake live server instead of PandaScore.
+func newTestSupervisor(server *fakeLiveServer) *Supervisor {
+	supervisor := NewSupervisor(pandascore.New().AccessToken("test_access_token"), pandascore.CSGO).
+		AccessToken("test_access_token")
+	supervisor.connect = func(match pandascore.Match) (*Client, bool) {
+		client, ok := ForMatch(match)
+		if ok {
+			liveURL, _ := url.Parse(match.Live.URL)
+			client.url = "ws" + server.URL[len("http"):] + liveURL.Path
+		}
+		return client, ok
+	}
+	return supervisor
+}
+
+func TestNewSupervisor(t *testing.T) {
+	result := NewSupervisor(pandascore.New(), pandascore.CSGO, pandascore.LoL)
+
+	assert.Equal(t, []pandascore.Game{pandascore.CSGO, pandascore.LoL}, result.games)
+	assert.Equal(t, DefaultPollInterval, result.pollInterval)
+	assert.Equal(t, DefaultMaxConnections, result.maxConnections)
+
+	result.PollInterval(-1).MaxConnections(0)
+	assert.Equal(t, DefaultPollInterval, result.pollInterval, "Expected invalid poll interval to be ignored")
+	assert.Equal(t, DefaultMaxConnections, result.maxConnections, "Expected invalid maximum connections to be ignored")
+}
+
+func TestSupervisor_Run(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Persist().
+		Reply(http.StatusOK).
+		File("../testdata/csgo-matches-running.json")
+	gock.New("https://api.pandascore.co/csgo/matches/upcoming").
+		Persist().
+		Reply(http.StatusOK).
+		BodyString("[]")
+
+	server := newFakeLiveServer(map[string][]string{"/matches/559177": {readTestdata("frame.json")}}, false)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streams := newTestSupervisor(server).MaxConnections(2).PollInterval(10 * time.Millisecond).Run(ctx)
+
+	first := <-streams
+	second := <-streams
+	assert.Equal(t, pandascore.CSGO, first.Game)
+	assert.Equal(t, []int{559177, 559176}, []int{first.Match.ID, second.Match.ID})
+
+	frame := <-first.Frames
+	assert.Equal(t, 559177, frame.MatchID)
+
+	select {
+	case stream := <-streams:
+		t.Errorf("Expected no more than 2 streams, got stream for match %d", stream.Match.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	_, open := <-streams
+	assert.False(t, open, "Expected streams channel to be closed once the supervisor is stopped")
+}
+
+func TestSupervisor_Run_disconnectsFinishedMatches(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Reply(http.StatusOK).
+		File("../testdata/csgo-matches-running.json")
+	gock.New("https://api.pandascore.co/csgo/matches/upcoming").
+		Persist().
+		Reply(http.StatusOK).
+		BodyString("[]")
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Persist().
+		Reply(http.StatusOK).
+		BodyString("[]")
+
+	server := newFakeLiveServer(map[string][]string{}, false)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streams := newTestSupervisor(server).MaxConnections(1).PollInterval(10 * time.Millisecond).Run(ctx)
+
+	stream := <-streams
+	_, open := <-stream.Frames
+	assert.False(t, open, "Expected stream to be closed once the match is no longer running")
+}
+
+func TestSupervisor_Run_waitsForLiveWindow(t *testing.T) {
+	defer gock.Off()
+
+	opensAt := time.Now().Add(200 * time.Millisecond).UTC().Truncate(time.Second).Add(time.Second)
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Persist().
+		Reply(http.StatusOK).
+		BodyString("[]")
+	gock.New("https://api.pandascore.co/csgo/matches/upcoming").
+		Persist().
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{{
+			"id":     1,
+			"status": "not_started",
+			"live": map[string]interface{}{
+				"supported": true,
+				"opens_at":  opensAt.Format(time.RFC3339),
+				"url":       "wss://live.pandascore.co/matches/1",
+			},
+		}})
+
+	server := newFakeLiveServer(map[string][]string{}, false)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streams := newTestSupervisor(server).PollInterval(time.Minute).Run(ctx)
+
+	stream := <-streams
+	assert.Equal(t, 1, stream.Match.ID)
+	assert.False(t, time.Now().Before(opensAt), "Expected match not to be connected to before its live window opens")
+}
+
+func TestSupervisor_Run_skipsUpcomingWithoutLiveWindow(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Persist().
+		Reply(http.StatusOK).
+		BodyString("[]")
+	gock.New("https://api.pandascore.co/csgo/matches/upcoming").
+		Persist().
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{{
+			"id":     1,
+			"status": "not_started",
+			"live":   map[string]interface{}{"supported": true, "url": "wss://live.pandascore.co/matches/1"},
+		}})
+
+	server := newFakeLiveServer(map[string][]string{}, false)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streams := newTestSupervisor(server).PollInterval(10 * time.Millisecond).Run(ctx)
+
+	select {
+	case stream := <-streams:
+		t.Errorf("Expected no stream for an upcoming match without live window, got match %d", stream.Match.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSupervisor_Run_freesClosedStreams(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Reply(http.StatusOK).
+		File("../testdata/csgo-matches-running.json")
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Persist().
+		Reply(http.StatusOK).
+		BodyString("[]")
+	gock.New("https://api.pandascore.co/csgo/matches/upcoming").
+		Persist().
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{{
+			"id":     1,
+			"status": "not_started",
+			"live": map[string]interface{}{
+				"supported": true,
+				"opens_at":  time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+				"url":       "wss://live.pandascore.co/matches/1",
+			},
+		}})
+
+	server := newFakeLiveServer(map[string][]string{}, false)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streams := newTestSupervisor(server).MaxConnections(1).PollInterval(10 * time.Millisecond).Run(ctx)
+
+	first := <-streams
+	assert.Equal(t, 559177, first.Match.ID)
+	second := <-streams
+	assert.Equal(t, 1, second.Match.ID)
+
+	select {
+	case _, open := <-first.Frames:
+		assert.False(t, open)
+	default:
+		t.Error("Expected the slot of the first match to be freed only once its stream is closed")
+	}
+}
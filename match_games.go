@@ -0,0 +1,99 @@
+package pandascore
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Returns the game (eg. a single map in CS:GO) with the given ID for the given video game.
+func (c *Client) GetGame(game Game, gameID int) (MatchGame, error) {
+	matchGame := new(MatchGame)
+	_, err := c.Request(game, "games/"+strconv.Itoa(gameID)).Get(matchGame)
+	return *matchGame, err
+}
+
+// MatchGame represents a single game played as part of a match, like a map in CS:GO. Not to be confused with Game,
+// which is the video game itself.
+type MatchGame struct {
+	ID         int             `json:"id"`
+	MatchID    int             `json:"match_id"`
+	Position   int             `json:"position"`
+	Status     string          `json:"status"`
+	Length     int             `json:"length"`
+	BeginsAt   time.Time       `json:"begin_at"`
+	EndsAt     time.Time       `json:"end_at"`
+	Finished   bool            `json:"finished"`
+	Forfeit    bool            `json:"forfeit"`
+	Winner     MatchGameWinner `json:"winner"`
+	WinnerType string          `json:"winner_type"`
+	VideoURL   string          `json:"video_url"`
+}
+
+// MatchGameWinner refers to the team or player that won a game; ID is 0 as long as the game has no winner.
+type MatchGameWinner struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+}
+
+// Returns true if the game is currently being played.
+func (g *MatchGame) IsRunning() bool {
+	return g.Status == "running"
+}
+
+// Returns the game of this match that is currently being played. If no game is being played, false is returned.
+func (m *Match) CurrentGame() (MatchGame, bool) {
+	for _, game := range m.Games {
+		if game.IsRunning() {
+			return game, true
+		}
+	}
+	return MatchGame{}, false
+}
+
+// Returns the number of games of this match won by the given opponent. Teams and players are numbered separately, so
+// both the type and the ID of the winner have to match.
+func (m *Match) GamesWon(opponent MatchOpponent) int {
+	won := 0
+	for _, game := range m.Games {
+		winnerType := game.Winner.Type
+		if len(winnerType) == 0 {
+			winnerType = game.WinnerType
+		}
+		if game.Winner.ID != 0 && game.Winner.ID == opponent.Opponent.ID && strings.EqualFold(winnerType, opponent.Type) {
+			won++
+		}
+	}
+	return won
+}
+
+// Returns true if one of the opponents has won enough games to win the match, even if not all games have been played.
+// For best-of matches that is more than half of the games; for first-to matches it's the number of games. Other types
+// of matches, and matches without a number of games, are only clinched once all games are finished.
+func (m *Match) IsClinched() bool {
+	if m.NumberOfGames > 0 {
+		switch m.MatchType {
+		case "best_of":
+			return m.mostGamesWon() > m.NumberOfGames/2
+		case "first_to":
+			return m.mostGamesWon() >= m.NumberOfGames
+		}
+	}
+
+	for _, game := range m.Games {
+		if !game.Finished {
+			return false
+		}
+	}
+	return len(m.Games) > 0
+}
+
+func (m *Match) mostGamesWon() int {
+	most := 0
+	for _, opponent := range m.Opponents {
+		if won := m.GamesWon(opponent); won > most {
+			most = won
+		}
+	}
+	return most
+}
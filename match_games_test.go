@@ -0,0 +1,101 @@
+package pandascore
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClient_GetGame(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/games/18412").
+		Reply(http.StatusOK).
+		File("testdata/csgo-game.json")
+
+	client := New()
+	result, err := client.GetGame(CSGO, 18412)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 18412, result.ID)
+	assert.Equal(t, 559177, result.MatchID)
+	assert.Equal(t, 1, result.Position)
+	assert.Equal(t, 3530, result.Length)
+	assert.Equal(t, time.Date(2020, time.April, 23, 14, 1, 1, 0, time.UTC), result.EndsAt)
+	assert.True(t, result.Finished)
+	assert.Equal(t, MatchGameWinner{ID: 3212, Type: "Team"}, result.Winner)
+}
+
+func TestMatch_CurrentGame(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-running.json")
+
+	matches, _ := New().GetAllRunningMatches(CSGO)
+	result, ok := matches[0].CurrentGame()
+
+	assert.True(t, ok)
+	assert.Equal(t, 2, result.Position)
+	assert.True(t, result.IsRunning())
+
+	_, ok = (&Match{}).CurrentGame()
+	assert.False(t, ok, "Expected no current game for a match without games")
+}
+
+func TestMatch_GamesWon(t *testing.T) {
+	match := Match{Games: []MatchGame{
+		{Winner: MatchGameWinner{ID: 1, Type: "Team"}},
+		{Winner: MatchGameWinner{ID: 2, Type: "Team"}},
+		{Winner: MatchGameWinner{ID: 1}, WinnerType: "Team"},
+		{Winner: MatchGameWinner{ID: 2, Type: "Player"}},
+		{},
+	}}
+
+	assert.Equal(t, 2, match.GamesWon(MatchOpponent{Type: "Team", Opponent: Opponent{ID: 1}}))
+	assert.Equal(t, 1, match.GamesWon(MatchOpponent{Type: "Team", Opponent: Opponent{ID: 2}}))
+	assert.Equal(t, 1, match.GamesWon(MatchOpponent{Type: "Player", Opponent: Opponent{ID: 2}}),
+		"Expected a player not to be credited with the games won by the team with the same ID")
+	assert.Equal(t, 0, match.GamesWon(MatchOpponent{Type: "Team", Opponent: Opponent{ID: 3}}))
+}
+
+func TestMatch_IsClinched(t *testing.T) {
+	opponents := []MatchOpponent{{Type: "Team", Opponent: Opponent{ID: 1}}, {Type: "Team", Opponent: Opponent{ID: 2}}}
+
+	bestOfThree := Match{MatchType: "best_of", NumberOfGames: 3, Opponents: opponents, Games: []MatchGame{
+		{Winner: MatchGameWinner{ID: 1, Type: "Team"}, Finished: true},
+		{Status: "running"},
+		{},
+	}}
+	assert.False(t, bestOfThree.IsClinched(), "Expected best of 3 not to be clinched after 1 win")
+
+	bestOfThree.Games[1] = MatchGame{Winner: MatchGameWinner{ID: 1, Type: "Team"}, Finished: true}
+	assert.True(t, bestOfThree.IsClinched(), "Expected best of 3 to be clinched after 2 wins")
+
+	firstToTwo := Match{MatchType: "first_to", NumberOfGames: 2, Opponents: opponents, Games: []MatchGame{
+		{Winner: MatchGameWinner{ID: 2, Type: "Team"}, Finished: true},
+		{Winner: MatchGameWinner{ID: 1, Type: "Team"}, Finished: true},
+		{Winner: MatchGameWinner{ID: 2, Type: "Team"}, Finished: true},
+	}}
+	assert.True(t, firstToTwo.IsClinched(), "Expected first to 2 to be clinched after 2 wins")
+
+	allGames := Match{MatchType: "all_games", NumberOfGames: 2, Opponents: opponents, Games: []MatchGame{
+		{Winner: MatchGameWinner{ID: 2, Type: "Team"}, Finished: true},
+		{},
+	}}
+	assert.False(t, allGames.IsClinched(), "Expected all games match not to be clinched before all games are finished")
+
+	unknown := Match{MatchType: "best_of", Opponents: opponents, Games: []MatchGame{
+		{Winner: MatchGameWinner{ID: 1, Type: "Team"}, Finished: true},
+		{},
+	}}
+	assert.False(t, unknown.IsClinched(),
+		"Expected match without number of games not to be clinched before all games are finished")
+	unknown.MatchType = "first_to"
+	assert.False(t, unknown.IsClinched())
+}
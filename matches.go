@@ -5,6 +5,29 @@ import (
 	"time"
 )
 
+// Returns the match with the given ID or slug.
+func (c *Client) GetMatch(idOrSlug string) (Match, error) {
+	match := new(Match)
+	_, err := c.RequestAllGames(resourcePath("matches", idOrSlug)).Get(match)
+	return *match, err
+}
+
+// Returns all matches for the given game, regardless of whether they're past, running or upcoming.
+func (c *Client) GetAllMatches(game Game) ([]Match, error) {
+	matches := new([]Match)
+	_, err := c.Request(game, "matches").PageSize(100).GetAll(matches)
+	return *matches, err
+}
+
+// Returns all matches for the given game that were modified at or after the given time, least recently modified first.
+// The second return value is the most recent modification time seen, which can be passed as since in the next call to
+// only fetch what changed in the meantime. Elements modified at exactly that time are returned again.
+func (c *Client) GetAllMatchesModifiedSince(game Game, since time.Time) ([]Match, time.Time, error) {
+	matches := new([]Match)
+	checkpoint, err := c.Request(game, "matches").PageSize(100).GetAllModifiedSince(since, matches)
+	return *matches, checkpoint, err
+}
+
 // Returns all upcoming matches for the given game & series ID.
 func (c *Client) GetAllUpcomingMatchesForSeries(game Game, seriesID int) ([]Match, error) {
 	matches := new([]Match)
@@ -14,6 +37,16 @@ func (c *Client) GetAllUpcomingMatchesForSeries(game Game, seriesID int) ([]Matc
 	return *matches, err
 }
 
+// Returns all upcoming matches in which the team with the given ID plays.
+func (c *Client) GetAllUpcomingMatchesForTeam(teamID int) ([]Match, error) {
+	matches := new([]Match)
+	_, err := c.RequestAllGames("matches/upcoming").
+		Filter("opponent_id", strconv.Itoa(teamID)).
+		PageSize(100).
+		GetAll(matches)
+	return *matches, err
+}
+
 // Returns all upcoming matches for the given game.
 func (c *Client) GetAllUpcomingMatches(game Game) ([]Match, error) {
 	matches := new([]Match)
@@ -40,19 +73,143 @@ func (c *Client) GetAllRunningMatches(game Game) ([]Match, error) {
 	return *matches, err
 }
 
+// Maximum time span of a single past matches request. Longer ranges are split into multiple windows of this size to
+// avoid paging deep into the results of a single request.
+const PastMatchesWindow = 30 * 24 * time.Hour
+
+// Returns all past matches for the given game.
+func (c *Client) GetAllPastMatches(game Game) ([]Match, error) {
+	matches := new([]Match)
+	_, err := c.Request(game, "matches/past").PageSize(100).GetAll(matches)
+	return *matches, err
+}
+
+// Returns all past matches for the given game that began between the given times, ordered by the time they began.
+//
+// Ranges longer than PastMatchesWindow are fetched one window at a time. Careful: the given times are always set to
+// UTC (Zulu) so timezones are not take into account.
+func (c *Client) GetAllPastMatchesBetween(game Game, beginning time.Time, until time.Time) ([]Match, error) {
+	var matches []Match
+	seen := make(map[int]bool)
+
+	for windowStart := beginning; windowStart.Before(until); windowStart = windowStart.Add(PastMatchesWindow) {
+		windowEnd := windowStart.Add(PastMatchesWindow)
+		if windowEnd.After(until) {
+			windowEnd = until
+		}
+
+		window := new([]Match)
+		_, err := c.Request(game, "matches/past").
+			Range("begin_at", windowStart.UTC().Format(time.RFC3339), windowEnd.UTC().Format(time.RFC3339)).
+			Sort("begin_at", Ascending).
+			PageSize(100).
+			GetAll(window)
+		if err != nil {
+			return matches, err
+		}
+
+		// Range bounds are inclusive, so matches beginning exactly on the edge of a window are returned twice
+		for _, match := range *window {
+			if !seen[match.ID] {
+				seen[match.ID] = true
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// Returns all past matches for the given game & league ID.
+func (c *Client) GetAllPastMatchesForLeague(game Game, leagueID int) ([]Match, error) {
+	return c.getAllPastMatchesFiltered(game, "league_id", leagueID)
+}
+
+// Returns all past matches for the given game & series ID.
+func (c *Client) GetAllPastMatchesForSeries(game Game, seriesID int) ([]Match, error) {
+	return c.getAllPastMatchesFiltered(game, "serie_id", seriesID)
+}
+
+// Returns all past matches for the given game in which the team with the given ID was one of the opponents.
+func (c *Client) GetAllPastMatchesForTeam(game Game, teamID int) ([]Match, error) {
+	return c.getAllPastMatchesFiltered(game, "opponent_id", teamID)
+}
+
+func (c *Client) getAllPastMatchesFiltered(game Game, field string, id int) ([]Match, error) {
+	matches := new([]Match)
+	_, err := c.Request(game, "matches/past").
+		Filter(field, strconv.Itoa(id)).
+		PageSize(100).
+		GetAll(matches)
+	return *matches, err
+}
+
 // Match represents an instance of a single match between 2 opponents (teams or players).
 //
 // More information: https://developers.pandascore.co/doc/#section/Introduction/Events-hierarchy
 type Match struct {
-	ID        int             `json:"id"`
-	Name      string          `json:"name"`
-	BeginsAt  time.Time       `json:"begin_at"`
-	Modified  time.Time       `json:"modified_at"`
-	LiveURL   string          `json:"live_url"`
-	Videogame Videogame       `json:"videogame"`
-	Opponents []MatchOpponent `json:"opponents"`
-	Series    Series          `json:"serie"`
-	League    League          `json:"league"`
+	ID            int             `json:"id"`
+	Name          string          `json:"name"`
+	Slug          string          `json:"slug"`
+	Status        string          `json:"status"`
+	MatchType     string          `json:"match_type"`
+	NumberOfGames int             `json:"number_of_games"`
+	BeginsAt      time.Time       `json:"begin_at"`
+	EndsAt        time.Time       `json:"end_at"`
+	ScheduledAt   time.Time       `json:"scheduled_at"`
+	Modified      time.Time       `json:"modified_at"`
+	LiveURL       string          `json:"live_url"`
+	Streams       []MatchStream   `json:"streams_list" pandascore:"optional"`
+	Live          MatchLive       `json:"live"`
+	Videogame     Videogame       `json:"videogame"`
+	Opponents     []MatchOpponent `json:"opponents"`
+	Results       []MatchResult   `json:"results"`
+	Games         []MatchGame     `json:"games"`
+	WinnerID      int             `json:"winner_id"`
+	Winner        Opponent        `json:"winner"`
+	Draw          bool            `json:"draw"`
+	Forfeit       bool            `json:"forfeit"`
+	Series        Series          `json:"serie"`
+	League        League          `json:"league"`
+	Tournament    Tournament      `json:"tournament"`
+}
+
+// Returns true if the match is over and the results are final.
+func (m *Match) IsFinished() bool {
+	return m.Status == "finished"
+}
+
+// Returns the score of the opponent with the given team or player ID, or 0 if that opponent isn't part of the match.
+func (m *Match) ScoreOf(opponentID int) int {
+	for _, result := range m.Results {
+		if result.OpponentID() == opponentID {
+			return result.Score
+		}
+	}
+	return 0
+}
+
+// MatchLive describes whether live data (frames and events) is available for a match over a WebSocket connection, and
+// from when it can be connected to.
+type MatchLive struct {
+	Supported bool      `json:"supported"`
+	OpensAt   time.Time `json:"opens_at"`
+	URL       string    `json:"url"`
+}
+
+// MatchResult represents the score of a single opponent in a match, which is either a team or a player.
+type MatchResult struct {
+	TeamID   int `json:"team_id"`
+	PlayerID int `json:"player_id"`
+	Score    int `json:"score"`
+}
+
+// Returns the ID of the team or player this result belongs to.
+func (r MatchResult) OpponentID() int {
+	if r.TeamID != 0 {
+		return r.TeamID
+	}
+	return r.PlayerID
 }
 
 // MatchOpponent represents an opponent as defined for a specific match. Whether the opponent is a team is defined on
@@ -62,11 +219,24 @@ type MatchOpponent struct {
 	Opponent Opponent `json:"opponent"`
 }
 
+// MatchStream is a single (video) stream of a match.
+type MatchStream struct {
+	Language string `json:"language"`
+	EmbedURL string `json:"embed_url"`
+	RawURL   string `json:"raw_url"`
+	Main     bool   `json:"main"`
+	Official bool   `json:"official"`
+}
+
 // Opponent represents a single opponent that partakes in a match.
 type Opponent struct {
-	Name     string `json:"name"`
-	Location string `json:"location"`
-	LogoURL  string `json:"image_url"`
+	ID       int       `json:"id"`
+	Name     string    `json:"name"`
+	Acronym  string    `json:"acronym"`
+	Slug     string    `json:"slug"`
+	Location string    `json:"location"`
+	LogoURL  string    `json:"image_url"`
+	Modified time.Time `json:"modified_at"`
 }
 
 // Videogame represents the type of game that this match is being played in.
@@ -2,6 +2,7 @@ package pandascore
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 	"time"
@@ -28,6 +29,22 @@ func TestClient_GetAllUpcomingMatchesForSeries(t *testing.T) {
 	assert.Len(t, result, 3)
 }
 
+func TestClient_GetAllUpcomingMatchesForTeam(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/matches/upcoming").
+		MatchParam("filter[opponent_id]", strconv.Itoa(3212)).
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-upcoming.json")
+
+	client := New()
+	result, err := client.GetAllUpcomingMatchesForTeam(3212)
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Len(t, result, 3)
+}
+
 func TestClient_GetAllUpcomingMatches(t *testing.T) {
 	defer gock.Off()
 	defer assert.True(t, gock.IsDone())
@@ -64,6 +81,14 @@ func TestClient_GetAllRunningMatches(t *testing.T) {
 	assert.Len(t, result, 4)
 	assert.Equal(t, "https://www.twitch.tv/esl_csgo", result[0].LiveURL)
 	assert.Equal(t, "https://cdn.pandascore.co/images/team/image/3212/FAZE_CLAN.png", result[0].Opponents[0].Opponent.LogoURL)
+	assert.Equal(t,
+		MatchLive{
+			Supported: true,
+			OpensAt:   time.Date(2020, time.April, 23, 12, 45, 9, 0, time.UTC),
+			URL:       "wss://live.pandascore.co/matches/559177",
+		},
+		result[0].Live,
+	)
 }
 
 func TestClient_GetAllUpcomingMatchesBetween(t *testing.T) {
@@ -86,3 +111,198 @@ func TestClient_GetAllUpcomingMatchesBetween(t *testing.T) {
 	assert.IsType(t, []Match{}, result)
 	assert.Len(t, result, 3)
 }
+
+func TestClient_GetMatch(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/matches/faze-vs-north-2020-04-23").
+		Reply(http.StatusOK).
+		File("testdata/csgo-match.json")
+
+	client := New()
+	result, err := client.GetMatch("faze-vs-north-2020-04-23")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 559177, result.ID)
+	assert.Equal(t, "faze-vs-north-2020-04-23", result.Slug)
+	assert.Equal(t, "Group b", result.Tournament.Name)
+	assert.Len(t, result.Opponents, 2)
+}
+
+func TestClient_GetMatch_notFound(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/matches/1").
+		Reply(http.StatusNotFound).
+		File("testdata/error-not-found.json")
+
+	client := New()
+	_, err := client.GetMatch("1")
+
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestClient_GetMatch_escapesSlug(t *testing.T) {
+	var requestURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURI = r.RequestURI
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := New().BaseURL(server.URL).GetMatch("../leagues")
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, "/matches/..%2Fleagues", requestURI, "Expected the slug not to point to a different endpoint")
+}
+
+func TestClient_GetAllPastMatches(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/matches/past").
+		MatchParam("page[size]", strconv.Itoa(100)).
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-past.json")
+
+	client := New()
+	result, err := client.GetAllPastMatches(CSGO)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+	assert.True(t, result[0].IsFinished())
+	assert.Equal(t, 3212, result[0].WinnerID)
+	assert.Equal(t, "FaZe", result[0].Winner.Name)
+	assert.Equal(t, time.Date(2020, time.April, 23, 16, 10, 42, 0, time.UTC), result[0].EndsAt)
+	assert.Equal(t, []MatchResult{{TeamID: 3212, Score: 2}, {TeamID: 3211, Score: 1}}, result[0].Results)
+	assert.Equal(t, 2, result[0].ScoreOf(3212))
+	assert.Equal(t, 1, result[0].ScoreOf(3211))
+	assert.Equal(t, 0, result[0].ScoreOf(1))
+}
+
+func TestClient_GetAllPastMatchesBetween(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	beginning := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	until := beginning.Add(45 * 24 * time.Hour)
+
+	gock.New("https://api.pandascore.co/csgo/matches/past").
+		MatchParam("range[begin_at]", "2020-03-01T00:00:00Z,2020-03-31T00:00:00Z").
+		MatchParam("sort", "begin_at").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-past.json")
+
+	gock.New("https://api.pandascore.co/csgo/matches/past").
+		MatchParam("range[begin_at]", "2020-03-31T00:00:00Z,2020-04-15T00:00:00Z").
+		MatchParam("sort", "begin_at").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-past.json")
+
+	client := New()
+	result, err := client.GetAllPastMatchesBetween(CSGO, beginning, until)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 3, "Expected matches returned by multiple windows to only be included once")
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []int{559177, 559176, 558412}, []int{result[0].ID, result[1].ID, result[2].ID})
+}
+
+func TestClient_GetAllPastMatchesForLeague(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/matches/past").
+		MatchParam("filter[league_id]", "4158").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-past.json")
+
+	client := New()
+	result, err := client.GetAllPastMatchesForLeague(CSGO, 4158)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+}
+
+func TestClient_GetAllPastMatchesForSeries(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/matches/past").
+		MatchParam("filter[serie_id]", "2626").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-past.json")
+
+	client := New()
+	result, err := client.GetAllPastMatchesForSeries(CSGO, 2626)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+}
+
+func TestClient_GetAllPastMatchesForTeam(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/matches/past").
+		MatchParam("filter[opponent_id]", "3212").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-past.json")
+
+	client := New()
+	result, err := client.GetAllPastMatchesForTeam(CSGO, 3212)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+}
+
+func TestClient_GetAllMatches(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/matches").
+		MatchParam("page[size]", strconv.Itoa(100)).
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-upcoming.json")
+
+	client := New()
+	result, err := client.GetAllMatches(CSGO)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+}
+
+func TestClient_GetAllMatchesModifiedSince(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches").
+		MatchParam("range[modified_at]", "2020-04-01T00:00:00Z,9999-12-31T23:59:59Z").
+		MatchParam("sort", "modified_at").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-upcoming.json")
+
+	client := New()
+	since := time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)
+	result, checkpoint, err := client.GetAllMatchesModifiedSince(CSGO, since)
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Len(t, result, 3)
+	assert.Equal(t, time.Date(2020, time.April, 4, 1, 6, 50, 0, time.UTC), checkpoint)
+}
+
+func TestClient_GetAllMatchesModifiedSince_error(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/matches").
+		Reply(http.StatusInternalServerError).
+		JSON(map[string]string{"error": "Internal server error"})
+
+	client := New()
+	since := time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)
+	_, checkpoint, err := client.GetAllMatchesModifiedSince(CSGO, since)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, since, checkpoint, "Expected the checkpoint not to move when the request fails")
+}
@@ -0,0 +1,156 @@
+// Keep a local copy of the leagues, series, tournaments and matches of a game.
+//
+// The first sync crawls everything the PandaScore API has for the game. Every following sync only fetches what was
+// modified since the previous sync and removes everything that was deleted in the meantime.
+package mirror
+
+import (
+	"time"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+const (
+	League     Kind = "league"
+	Series     Kind = "serie"
+	Tournament Kind = "tournament"
+	Match      Kind = "match"
+
+	// Teams and players aren't synced by the engine, but can be put in a store directly
+	Team   Kind = "team"
+	Player Kind = "player"
+)
+
+// Kind is the kind of entity that is stored. The values match the incident types of the PandaScore change feeds.
+type Kind string
+
+// All kinds of entities synced by the engine, in the order they are synced.
+var Kinds = []Kind{League, Series, Tournament, Match}
+
+// Engine syncs all entities of a single game into a store.
+type Engine struct {
+	client *pandascore.Client
+	game   pandascore.Game
+	store  Store
+}
+
+// Construct a new engine that syncs the given game into the given store using the given client.
+func New(client *pandascore.Client, game pandascore.Game, store Store) *Engine {
+	return &Engine{client: client, game: game, store: store}
+}
+
+// Sync the store with the PandaScore API. Without a checkpoint in the store, all entities are fetched; otherwise only
+// the entities modified since the checkpoint are fetched and deleted entities are removed from the store. The
+// checkpoint is moved to the most recent modification time seen, so it doesn't depend on the local clock, and only if
+// the complete sync succeeded.
+func (e *Engine) Sync() error {
+	checkpoint, err := e.store.Checkpoint(e.game)
+	if err != nil {
+		return err
+	}
+
+	next := checkpoint
+	for _, kind := range Kinds {
+		request := e.client.Request(e.game, paths[kind]).PageSize(100)
+		entities, modified, err := fetch(kind, request, checkpoint)
+		if err != nil {
+			return err
+		}
+		if modified.After(next) {
+			next = modified
+		}
+		for _, entity := range entities {
+			if err := e.store.Put(kind, entity.id, entity.modified, entity.value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !checkpoint.IsZero() {
+		if err := e.applyDeletions(checkpoint); err != nil {
+			return err
+		}
+	}
+
+	return e.store.SetCheckpoint(e.game, next)
+}
+
+// Remove all entities that were deleted since the given time from the store.
+func (e *Engine) applyDeletions(since time.Time) error {
+	types := make([]pandascore.IncidentType, len(Kinds))
+	for index, kind := range Kinds {
+		types[index] = pandascore.IncidentType(kind)
+	}
+
+	incidents, err := e.client.GetAllIncidents(pandascore.DeletionsFeed, since, types...)
+	if err != nil {
+		return err
+	}
+
+	for _, incident := range incidents {
+		if !incident.IsDeletion() {
+			continue
+		}
+		if err := e.store.Delete(Kind(incident.Type), incident.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var paths = map[Kind]string{
+	League:     "leagues",
+	Series:     "series",
+	Tournament: "tournaments",
+	Match:      "matches",
+}
+
+// A single entity fetched from the API with the fields every store needs.
+type entity struct {
+	id       int
+	modified time.Time
+	value    interface{}
+}
+
+// Fetch all entities of the given kind modified since the given time with the given request, along with the most
+// recent modification time seen.
+func fetch(kind Kind, request *pandascore.Request, since time.Time) ([]entity, time.Time, error) {
+	var entities []entity
+	var value interface{}
+
+	switch kind {
+	case League:
+		value = new([]pandascore.League)
+	case Series:
+		value = new([]pandascore.Series)
+	case Tournament:
+		value = new([]pandascore.Tournament)
+	case Match:
+		value = new([]pandascore.Match)
+	}
+	modified, err := request.GetAllModifiedSince(since, value)
+	if err != nil {
+		return nil, since, err
+	}
+
+	switch elements := value.(type) {
+	case *[]pandascore.League:
+		for _, league := range *elements {
+			entities = append(entities, entity{id: league.ID, modified: league.Modified, value: league})
+		}
+	case *[]pandascore.Series:
+		for _, serie := range *elements {
+			entities = append(entities, entity{id: serie.ID, modified: serie.Modified, value: serie})
+		}
+	case *[]pandascore.Tournament:
+		for _, tournament := range *elements {
+			entities = append(entities, entity{id: tournament.ID, modified: tournament.Modified, value: tournament})
+		}
+	case *[]pandascore.Match:
+		for _, match := range *elements {
+			entities = append(entities, entity{id: match.ID, modified: match.Modified, value: match})
+		}
+	}
+
+	return entities, modified, nil
+}
@@ -0,0 +1,89 @@
+package mirror
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestEngine_Sync_fullCrawl(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/leagues").Reply(http.StatusOK).File("../testdata/csgo-leagues.json")
+	gock.New("https://api.pandascore.co/csgo/series").Reply(http.StatusOK).File("../testdata/csgo-series-running.json")
+	gock.New("https://api.pandascore.co/csgo/tournaments").Reply(http.StatusOK).File("../testdata/csgo-tournaments.json")
+	gock.New("https://api.pandascore.co/csgo/matches").Reply(http.StatusOK).File("../testdata/csgo-matches-upcoming.json")
+
+	store := NewMemoryStore()
+	err := New(pandascore.New(), pandascore.CSGO, store).Sync()
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 50, store.Count(League))
+	assert.Equal(t, 2, store.Count(Series))
+	assert.Equal(t, 3, store.Count(Tournament))
+	assert.Equal(t, 3, store.Count(Match))
+
+	match := pandascore.Match{}
+	found, err := store.Get(Match, 556658, &match)
+	assert.True(t, found)
+	assert.Nil(t, err)
+	assert.Equal(t, "FaZe vs forZe", match.Name)
+
+	checkpoint, _ := store.Checkpoint(pandascore.CSGO)
+	assert.Equal(t, time.Date(2020, time.April, 4, 1, 6, 50, 0, time.UTC), checkpoint,
+		"Expected the checkpoint to be the most recent modification time seen")
+}
+
+func TestEngine_Sync_incremental(t *testing.T) {
+	defer gock.Off()
+
+	checkpoint := time.Date(2020, time.April, 23, 13, 0, 0, 0, time.UTC)
+	modifiedRange := "2020-04-23T13:00:00Z,9999-12-31T23:59:59Z"
+
+	gock.New("https://api.pandascore.co/csgo/leagues").MatchParam("range[modified_at]", modifiedRange).
+		Reply(http.StatusOK).BodyString("[]")
+	gock.New("https://api.pandascore.co/csgo/series").MatchParam("range[modified_at]", modifiedRange).
+		Reply(http.StatusOK).BodyString("[]")
+	gock.New("https://api.pandascore.co/csgo/tournaments").MatchParam("range[modified_at]", modifiedRange).
+		Reply(http.StatusOK).BodyString("[]")
+	gock.New("https://api.pandascore.co/csgo/matches").MatchParam("range[modified_at]", modifiedRange).
+		Reply(http.StatusOK).File("../testdata/csgo-matches-running.json")
+	gock.New("https://api.pandascore.co/deletions").MatchParam("since", "2020-04-23T13:00:00Z").
+		Reply(http.StatusOK).File("../testdata/incidents.json")
+
+	store := NewMemoryStore()
+	_ = store.SetCheckpoint(pandascore.CSGO, checkpoint)
+	_ = store.Put(Match, 556012, checkpoint.Add(-time.Hour), pandascore.Match{ID: 556012})
+	_ = store.Put(Series, 2522, checkpoint.Add(-time.Hour), pandascore.Series{ID: 2522})
+
+	err := New(pandascore.New(), pandascore.CSGO, store).Sync()
+
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 3, store.Count(Match), "Expected modified matches to be added and deleted match to be removed")
+	found, _ := store.Get(Match, 556012, &pandascore.Match{})
+	assert.False(t, found)
+	assert.Equal(t, 1, store.Count(Series), "Expected only deletions to be applied from the deletions feed")
+
+	newCheckpoint, _ := store.Checkpoint(pandascore.CSGO)
+	assert.Equal(t, time.Date(2020, time.April, 23, 14, 32, 25, 0, time.UTC), newCheckpoint)
+}
+
+func TestEngine_Sync_failureKeepsCheckpoint(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/leagues").Reply(http.StatusForbidden).
+		File("../testdata/error-missing-access-token.json")
+
+	store := NewMemoryStore()
+	err := New(pandascore.New(), pandascore.CSGO, store).Sync()
+
+	assert.NotNil(t, err)
+	checkpoint, _ := store.Checkpoint(pandascore.CSGO)
+	assert.True(t, checkpoint.IsZero())
+}
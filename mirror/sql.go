@@ -0,0 +1,401 @@
+package mirror
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+const (
+	// SQLite 3.24 or newer; uses ? placeholders
+	SQLite Dialect = 0
+
+	// PostgreSQL 9.5 or newer; uses $1, $2, ... placeholders
+	PostgreSQL Dialect = 1
+)
+
+// Dialect of the database used by SQLStore. Both supported dialects share the same schema and upsert syntax; they only
+// differ in placeholders.
+type Dialect byte
+
+// SQLStore keeps all entities in a relational database through database/sql, so they can be queried for analytics.
+//
+// Every entity has its own table (leagues, series, tournaments, matches, games, teams and players) with its most
+// useful fields as columns and the complete entity as JSON in the data column. Games and opponents are stored along
+// with their match and players along with their team. Entities are upserted by their PandaScore ID and only updated
+// if they were modified at the same time or later than the stored version. The opponents of a match only carry part
+// of a team, so they're only inserted if the team isn't stored yet and never replace it.
+//
+// The store doesn't import any database driver; that's up to the caller.
+type SQLStore struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// Construct a new store on top of the given database. Call Migrate to create or update the schema before use.
+func NewSQLStore(db *sql.DB, dialect Dialect) *SQLStore {
+	return &SQLStore{db: db, dialect: dialect}
+}
+
+// Schema migrations, applied in order. Never change a migration once it's released; add a new one instead.
+var migrations = []string{
+	`CREATE TABLE leagues (
+		id BIGINT PRIMARY KEY,
+		modified_at TIMESTAMP NOT NULL,
+		data TEXT NOT NULL,
+		name TEXT,
+		slug TEXT,
+		image_url TEXT
+	)`,
+	`CREATE TABLE series (
+		id BIGINT PRIMARY KEY,
+		modified_at TIMESTAMP NOT NULL,
+		data TEXT NOT NULL,
+		league_id BIGINT,
+		name TEXT,
+		full_name TEXT,
+		slug TEXT,
+		season TEXT,
+		year INTEGER,
+		begin_at TIMESTAMP,
+		end_at TIMESTAMP,
+		winner_id BIGINT
+	)`,
+	`CREATE TABLE tournaments (
+		id BIGINT PRIMARY KEY,
+		modified_at TIMESTAMP NOT NULL,
+		data TEXT NOT NULL,
+		league_id BIGINT,
+		serie_id BIGINT,
+		name TEXT,
+		slug TEXT,
+		begin_at TIMESTAMP,
+		end_at TIMESTAMP,
+		winner_id BIGINT
+	)`,
+	`CREATE TABLE matches (
+		id BIGINT PRIMARY KEY,
+		modified_at TIMESTAMP NOT NULL,
+		data TEXT NOT NULL,
+		league_id BIGINT,
+		serie_id BIGINT,
+		tournament_id BIGINT,
+		name TEXT,
+		slug TEXT,
+		status TEXT,
+		scheduled_at TIMESTAMP,
+		begin_at TIMESTAMP,
+		end_at TIMESTAMP,
+		winner_id BIGINT
+	)`,
+	`CREATE TABLE games (
+		id BIGINT PRIMARY KEY,
+		modified_at TIMESTAMP NOT NULL,
+		data TEXT NOT NULL,
+		match_id BIGINT,
+		position INTEGER,
+		status TEXT,
+		length INTEGER,
+		begin_at TIMESTAMP,
+		end_at TIMESTAMP,
+		winner_id BIGINT
+	)`,
+	`CREATE TABLE teams (
+		id BIGINT PRIMARY KEY,
+		modified_at TIMESTAMP NOT NULL,
+		data TEXT NOT NULL,
+		name TEXT,
+		acronym TEXT,
+		slug TEXT,
+		location TEXT
+	)`,
+	`CREATE TABLE players (
+		id BIGINT PRIMARY KEY,
+		modified_at TIMESTAMP NOT NULL,
+		data TEXT NOT NULL,
+		team_id BIGINT,
+		name TEXT,
+		first_name TEXT,
+		last_name TEXT,
+		slug TEXT,
+		nationality TEXT,
+		role TEXT
+	)`,
+	`CREATE TABLE checkpoints (
+		game TEXT PRIMARY KEY,
+		checkpoint TIMESTAMP NOT NULL
+	)`,
+	`CREATE INDEX matches_league_id ON matches (league_id)`,
+	`CREATE INDEX matches_serie_id ON matches (serie_id)`,
+	`CREATE INDEX games_match_id ON games (match_id)`,
+}
+
+var tables = map[Kind]string{
+	League:     "leagues",
+	Series:     "series",
+	Tournament: "tournaments",
+	Match:      "matches",
+	Team:       "teams",
+	Player:     "players",
+}
+
+// Create the schema or bring it up to date by applying all migrations that haven't been applied yet. Every migration
+// is applied in its own transaction.
+func (s *SQLStore) Migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL)`); err != nil {
+		return err
+	}
+
+	var version int
+	if err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return err
+	}
+
+	for index := version; index < len(migrations); index++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[index]); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("schema migration %d failed: %w", index+1, err)
+		}
+		if _, err := tx.Exec(s.rebind(`INSERT INTO schema_migrations (version) VALUES (?)`), index+1); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) Put(kind Kind, id int, modified time.Time, value interface{}) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := s.put(tx, kind, id, modified, value); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLStore) put(tx *sql.Tx, kind Kind, id int, modified time.Time, value interface{}) error {
+	switch v := value.(type) {
+	case pandascore.League:
+		return s.upsert(tx, "leagues", id, modified, v,
+			column{"name", v.Name}, column{"slug", v.Slug}, column{"image_url", v.ImageURL})
+
+	case pandascore.Series:
+		return s.upsert(tx, "series", id, modified, v,
+			column{"league_id", v.LeagueID}, column{"name", v.Name}, column{"full_name", v.FullName},
+			column{"slug", v.Slug}, column{"season", v.Season}, column{"year", v.Year},
+			column{"begin_at", nullTime(v.BeginsAt)}, column{"end_at", nullTime(v.EndsAt)},
+			column{"winner_id", v.WinnerID})
+
+	case pandascore.Tournament:
+		return s.upsert(tx, "tournaments", id, modified, v,
+			column{"league_id", v.LeagueID}, column{"serie_id", v.SeriesID}, column{"name", v.Name},
+			column{"slug", v.Slug}, column{"begin_at", nullTime(v.BeginsAt)}, column{"end_at", nullTime(v.EndsAt)},
+			column{"winner_id", v.WinnerID})
+
+	case pandascore.Match:
+		err := s.upsert(tx, "matches", id, modified, v,
+			column{"league_id", v.League.ID}, column{"serie_id", v.Series.ID}, column{"tournament_id", v.Tournament.ID},
+			column{"name", v.Name}, column{"slug", v.Slug}, column{"status", v.Status},
+			column{"scheduled_at", nullTime(v.ScheduledAt)}, column{"begin_at", nullTime(v.BeginsAt)},
+			column{"end_at", nullTime(v.EndsAt)}, column{"winner_id", v.WinnerID})
+		if err != nil {
+			return err
+		}
+		for _, game := range v.Games {
+			err := s.upsert(tx, "games", game.ID, modified, game,
+				column{"match_id", id}, column{"position", game.Position}, column{"status", game.Status},
+				column{"length", game.Length}, column{"begin_at", nullTime(game.BeginsAt)},
+				column{"end_at", nullTime(game.EndsAt)}, column{"winner_id", game.Winner.ID})
+			if err != nil {
+				return err
+			}
+		}
+		for _, opponent := range v.Opponents {
+			if opponent.Type != "Team" || opponent.Opponent.ID == 0 {
+				continue
+			}
+			team := opponent.Opponent
+			err := s.insertMissing(tx, "teams", team.ID, team.Modified, team,
+				column{"name", team.Name}, column{"acronym", team.Acronym}, column{"slug", team.Slug},
+				column{"location", team.Location})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case pandascore.Team:
+		err := s.upsert(tx, "teams", id, modified, v,
+			column{"name", v.Name}, column{"acronym", v.Acronym}, column{"slug", v.Slug}, column{"location", v.Location})
+		if err != nil {
+			return err
+		}
+		for _, player := range v.Players {
+			player.CurrentTeam = pandascore.Team{ID: v.ID, Name: v.Name}
+			playerModified := player.Modified
+			if playerModified.IsZero() {
+				playerModified = modified
+			}
+			if err := s.put(tx, Player, player.ID, playerModified, player); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case pandascore.Player:
+		return s.upsert(tx, "players", id, modified, v,
+			column{"team_id", v.CurrentTeam.ID}, column{"name", v.Name}, column{"first_name", v.FirstName},
+			column{"last_name", v.LastName}, column{"slug", v.Slug}, column{"nationality", v.Nationality},
+			column{"role", v.Role})
+
+	default:
+		return fmt.Errorf("unsupported %s entity of type %T", kind, value)
+	}
+}
+
+// A single column and its value for an upsert.
+type column struct {
+	name  string
+	value interface{}
+}
+
+// Insert the given entity or update it if it already exists and wasn't modified later than the given time.
+func (s *SQLStore) upsert(tx *sql.Tx, table string, id int, modified time.Time, value interface{}, columns ...column) error {
+	return s.insert(tx, table, id, modified, value, true, columns...)
+}
+
+// Insert the given entity unless it already exists.
+func (s *SQLStore) insertMissing(tx *sql.Tx, table string, id int, modified time.Time, value interface{}, columns ...column) error {
+	return s.insert(tx, table, id, modified, value, false, columns...)
+}
+
+// Insert the given entity, and if it already exists either update it (if it wasn't modified later than the given
+// time) or leave it alone.
+func (s *SQLStore) insert(tx *sql.Tx, table string, id int, modified time.Time, value interface{}, update bool, columns ...column) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	columns = append([]column{{"id", id}, {"modified_at", modified.UTC()}, {"data", string(data)}}, columns...)
+	names := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	updates := make([]string, 0, len(columns)-1)
+	values := make([]interface{}, len(columns))
+	for index, column := range columns {
+		names[index] = column.name
+		placeholders[index] = "?"
+		values[index] = column.value
+		if column.name != "id" {
+			updates = append(updates, column.name+" = excluded."+column.name)
+		}
+	}
+
+	query := "INSERT INTO " + table + " (" + strings.Join(names, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	if update {
+		query += " ON CONFLICT (id) DO UPDATE SET " + strings.Join(updates, ", ") +
+			" WHERE excluded.modified_at >= " + table + ".modified_at"
+	} else {
+		query += " ON CONFLICT (id) DO NOTHING"
+	}
+	_, err = tx.Exec(s.rebind(query), values...)
+	return err
+}
+
+func (s *SQLStore) Delete(kind Kind, id int) error {
+	table, ok := tables[kind]
+	if !ok {
+		return fmt.Errorf("unsupported entity kind %s", kind)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if kind == Match {
+		if _, err := tx.Exec(s.rebind(`DELETE FROM games WHERE match_id = ?`), id); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.Exec(s.rebind(`DELETE FROM `+table+` WHERE id = ?`), id); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLStore) Get(kind Kind, id int, value interface{}) (bool, error) {
+	table, ok := tables[kind]
+	if !ok {
+		return false, fmt.Errorf("unsupported entity kind %s", kind)
+	}
+
+	var data string
+	err := s.db.QueryRow(s.rebind(`SELECT data FROM `+table+` WHERE id = ?`), id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal([]byte(data), value)
+}
+
+func (s *SQLStore) Checkpoint(game pandascore.Game) (time.Time, error) {
+	var checkpoint time.Time
+	err := s.db.QueryRow(s.rebind(`SELECT checkpoint FROM checkpoints WHERE game = ?`), string(game)).Scan(&checkpoint)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	return checkpoint.UTC(), err
+}
+
+func (s *SQLStore) SetCheckpoint(game pandascore.Game, checkpoint time.Time) error {
+	_, err := s.db.Exec(
+		s.rebind(`INSERT INTO checkpoints (game, checkpoint) VALUES (?, ?) ON CONFLICT (game) DO UPDATE SET checkpoint = excluded.checkpoint`),
+		string(game), checkpoint.UTC(),
+	)
+	return err
+}
+
+// Replace the ? placeholders in the given query with the placeholders of the dialect.
+func (s *SQLStore) rebind(query string) string {
+	if s.dialect != PostgreSQL {
+		return query
+	}
+
+	var builder strings.Builder
+	parameter := 0
+	for _, character := range query {
+		if character == '?' {
+			parameter++
+			builder.WriteString("$" + strconv.Itoa(parameter))
+		} else {
+			builder.WriteRune(character)
+		}
+	}
+	return builder.String()
+}
+
+// Returns nil for the zero time so it's stored as NULL.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
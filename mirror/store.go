@@ -0,0 +1,229 @@
+package mirror
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// Store persists the entities synced by the engine.
+type Store interface {
+	// Stores the given entity, unless the store already has a version of it that was modified later
+	Put(kind Kind, id int, modified time.Time, value interface{}) error
+
+	// Removes the entity with the given kind and ID; removing an entity that isn't stored is not an error
+	Delete(kind Kind, id int) error
+
+	// Decodes the stored entity with the given kind and ID in the struct pointed to by value. Returns false if the
+	// entity isn't stored.
+	Get(kind Kind, id int, value interface{}) (bool, error)
+
+	// Returns the time of the last successful sync of the given game, or the zero time if it was never synced
+	Checkpoint(game pandascore.Game) (time.Time, error)
+
+	// Stores the time of the last successful sync of the given game
+	SetCheckpoint(game pandascore.Game, checkpoint time.Time) error
+}
+
+// An entity as it's kept by the stores in this package.
+type storedEntity struct {
+	Modified time.Time       `json:"modified_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// MemoryStore keeps all entities in memory, which is mostly useful for tests and short-lived programs.
+type MemoryStore struct {
+	mutex       sync.RWMutex
+	entities    map[Kind]map[int]storedEntity
+	checkpoints map[pandascore.Game]time.Time
+}
+
+// Construct a new, empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entities:    make(map[Kind]map[int]storedEntity),
+		checkpoints: make(map[pandascore.Game]time.Time),
+	}
+}
+
+func (s *MemoryStore) Put(kind Kind, id int, modified time.Time, value interface{}) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.entities[kind] == nil {
+		s.entities[kind] = make(map[int]storedEntity)
+	}
+	if existing, ok := s.entities[kind][id]; ok && existing.Modified.After(modified) {
+		return nil
+	}
+	s.entities[kind][id] = storedEntity{Modified: modified, Value: content}
+	return nil
+}
+
+func (s *MemoryStore) Delete(kind Kind, id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.entities[kind], id)
+	return nil
+}
+
+func (s *MemoryStore) Get(kind Kind, id int, value interface{}) (bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stored, ok := s.entities[kind][id]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(stored.Value, value)
+}
+
+// Returns the number of stored entities of the given kind.
+func (s *MemoryStore) Count(kind Kind) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.entities[kind])
+}
+
+func (s *MemoryStore) Checkpoint(game pandascore.Game) (time.Time, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.checkpoints[game], nil
+}
+
+func (s *MemoryStore) SetCheckpoint(game pandascore.Game, checkpoint time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.checkpoints[game] = checkpoint
+	return nil
+}
+
+// FileStore keeps every entity in its own JSON file on disk, in a directory per kind (eg. match/559177.json). The
+// checkpoints of all games are kept in checkpoints.json.
+type FileStore struct {
+	mutex     sync.Mutex
+	directory string
+}
+
+// Construct a new store that keeps its files in the given directory, which is created if it doesn't exist yet.
+func NewFileStore(directory string) (*FileStore, error) {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{directory: directory}, nil
+}
+
+func (s *FileStore) Put(kind Kind, id int, modified time.Time, value interface{}) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, ok, err := s.read(kind, id)
+	if err != nil {
+		return err
+	}
+	if ok && existing.Modified.After(modified) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(s.directory, string(kind)), 0755); err != nil {
+		return err
+	}
+	return writeJSON(s.path(kind, id), storedEntity{Modified: modified, Value: content})
+}
+
+func (s *FileStore) Delete(kind Kind, id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := os.Remove(s.path(kind, id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileStore) Get(kind Kind, id int, value interface{}) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, ok, err := s.read(kind, id)
+	if err != nil || !ok {
+		return false, err
+	}
+	return true, json.Unmarshal(stored.Value, value)
+}
+
+func (s *FileStore) Checkpoint(game pandascore.Game) (time.Time, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	checkpoints, err := s.readCheckpoints()
+	return checkpoints[game], err
+}
+
+func (s *FileStore) SetCheckpoint(game pandascore.Game, checkpoint time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	checkpoints, err := s.readCheckpoints()
+	if err != nil {
+		return err
+	}
+	checkpoints[game] = checkpoint
+	return writeJSON(filepath.Join(s.directory, "checkpoints.json"), checkpoints)
+}
+
+func (s *FileStore) path(kind Kind, id int) string {
+	return filepath.Join(s.directory, string(kind), strconv.Itoa(id)+".json")
+}
+
+func (s *FileStore) read(kind Kind, id int) (storedEntity, bool, error) {
+	stored := storedEntity{}
+	content, err := ioutil.ReadFile(s.path(kind, id))
+	if os.IsNotExist(err) {
+		return stored, false, nil
+	} else if err != nil {
+		return stored, false, err
+	}
+	return stored, true, json.Unmarshal(content, &stored)
+}
+
+func (s *FileStore) readCheckpoints() (map[pandascore.Game]time.Time, error) {
+	checkpoints := make(map[pandascore.Game]time.Time)
+	content, err := ioutil.ReadFile(filepath.Join(s.directory, "checkpoints.json"))
+	if os.IsNotExist(err) {
+		return checkpoints, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return checkpoints, json.Unmarshal(content, &checkpoints)
+}
+
+// Write the given value as JSON to a temporary file first and move it in place afterwards, so a crash never leaves a
+// half written file behind.
+func writeJSON(path string, value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".tmp", content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,181 @@
+package mirror
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmbrggmn/pandascore-go"
+)
+
+// Runs the same assertions against every store implementation.
+func testStore(t *testing.T, store Store) {
+	modified := time.Date(2020, time.April, 23, 13, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, store.Put(League, 4158, modified, pandascore.League{ID: 4158, Name: "ESL"}))
+
+	league := pandascore.League{}
+	found, err := store.Get(League, 4158, &league)
+	assert.True(t, found)
+	assert.Nil(t, err)
+	assert.Equal(t, "ESL", league.Name)
+
+	assert.Nil(t, store.Put(League, 4158, modified.Add(-time.Hour), pandascore.League{ID: 4158, Name: "Older"}))
+	_, _ = store.Get(League, 4158, &league)
+	assert.Equal(t, "ESL", league.Name, "Expected older versions not to overwrite newer ones")
+
+	assert.Nil(t, store.Put(League, 4158, modified.Add(time.Hour), pandascore.League{ID: 4158, Name: "Newer"}))
+	_, _ = store.Get(League, 4158, &league)
+	assert.Equal(t, "Newer", league.Name)
+
+	assert.Nil(t, store.Delete(League, 4158))
+	found, err = store.Get(League, 4158, &league)
+	assert.False(t, found)
+	assert.Nil(t, err)
+	assert.Nil(t, store.Delete(League, 4158), "Expected deleting a missing entity not to fail")
+
+	checkpoint, err := store.Checkpoint(pandascore.CSGO)
+	assert.Nil(t, err)
+	assert.True(t, checkpoint.IsZero())
+	assert.Nil(t, store.SetCheckpoint(pandascore.CSGO, modified))
+	assert.Nil(t, store.SetCheckpoint(pandascore.LoL, modified.Add(time.Hour)))
+	checkpoint, _ = store.Checkpoint(pandascore.CSGO)
+	assert.Equal(t, modified, checkpoint)
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	directory, _ := ioutil.TempDir("", "pandascore-mirror")
+	defer os.RemoveAll(directory)
+
+	store, err := NewFileStore(filepath.Join(directory, "csgo"))
+	assert.Nil(t, err)
+
+	testStore(t, store)
+}
+
+func TestFileStore_layout(t *testing.T) {
+	directory, _ := ioutil.TempDir("", "pandascore-mirror")
+	defer os.RemoveAll(directory)
+
+	store, _ := NewFileStore(directory)
+	_ = store.Put(Match, 559177, time.Now(), pandascore.Match{ID: 559177})
+
+	_, err := os.Stat(filepath.Join(directory, "match", "559177.json"))
+	assert.Nil(t, err)
+}
+
+func newSQLiteStore(t *testing.T) (*SQLStore, *sql.DB) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	db.SetMaxOpenConns(1)
+
+	store := NewSQLStore(db, SQLite)
+	assert.Nil(t, store.Migrate())
+	return store, db
+}
+
+func TestSQLStore(t *testing.T) {
+	store, db := newSQLiteStore(t)
+	defer db.Close()
+
+	testStore(t, store)
+}
+
+func TestSQLStore_Migrate(t *testing.T) {
+	store, db := newSQLiteStore(t)
+	defer db.Close()
+
+	assert.Nil(t, store.Migrate(), "Expected migrating an up to date schema to be a no-op")
+
+	var version int
+	_ = db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version)
+	assert.Equal(t, len(migrations), version)
+}
+
+func TestSQLStore_match(t *testing.T) {
+	store, db := newSQLiteStore(t)
+	defer db.Close()
+
+	match := pandascore.Match{}
+	_ = json.Unmarshal(readTestdata(t, "csgo-match.json"), &match)
+	assert.Nil(t, store.Put(Match, match.ID, match.Modified, match))
+
+	var status string
+	var leagueID int
+	_ = db.QueryRow(`SELECT status, league_id FROM matches WHERE id = ?`, match.ID).Scan(&status, &leagueID)
+	assert.Equal(t, match.Status, status)
+	assert.Equal(t, match.League.ID, leagueID)
+
+	var games, teams int
+	_ = db.QueryRow(`SELECT COUNT(*) FROM games WHERE match_id = ?`, match.ID).Scan(&games)
+	_ = db.QueryRow(`SELECT COUNT(*) FROM teams`).Scan(&teams)
+	assert.Equal(t, len(match.Games), games)
+	assert.Equal(t, len(match.Opponents), teams)
+
+	assert.Nil(t, store.Delete(Match, match.ID))
+	_ = db.QueryRow(`SELECT COUNT(*) FROM games WHERE match_id = ?`, match.ID).Scan(&games)
+	assert.Equal(t, 0, games, "Expected the games of a deleted match to be deleted as well")
+}
+
+func TestSQLStore_team(t *testing.T) {
+	store, db := newSQLiteStore(t)
+	defer db.Close()
+
+	team := pandascore.Team{}
+	_ = json.Unmarshal(readTestdata(t, "csgo-team.json"), &team)
+	assert.Nil(t, store.Put(Team, team.ID, team.Modified, team))
+
+	var players int
+	_ = db.QueryRow(`SELECT COUNT(*) FROM players WHERE team_id = ?`, team.ID).Scan(&players)
+	assert.Equal(t, len(team.Players), players)
+	assert.NotZero(t, players)
+}
+
+func TestSQLStore_teamOfMatch(t *testing.T) {
+	store, db := newSQLiteStore(t)
+	defer db.Close()
+
+	team := pandascore.Team{}
+	_ = json.Unmarshal(readTestdata(t, "csgo-team.json"), &team)
+	team.Players[0].Modified = team.Modified.Add(-time.Hour)
+	assert.Nil(t, store.Put(Team, team.ID, team.Modified, team))
+
+	var playerModified time.Time
+	_ = db.QueryRow(`SELECT modified_at FROM players WHERE id = ?`, team.Players[0].ID).Scan(&playerModified)
+	assert.True(t, team.Players[0].Modified.Equal(playerModified),
+		"Expected players to be stored with their own modification time")
+
+	match := pandascore.Match{}
+	_ = json.Unmarshal(readTestdata(t, "csgo-match.json"), &match)
+	for index := range match.Opponents {
+		match.Opponents[index].Opponent.Modified = team.Modified.Add(time.Hour)
+	}
+	assert.Nil(t, store.Put(Match, match.ID, match.Modified, match))
+
+	stored := pandascore.Team{}
+	ok, err := store.Get(Team, team.ID, &stored)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Len(t, stored.Players, len(team.Players), "Expected the opponents of a match not to replace the team")
+}
+
+func TestSQLStore_rebind(t *testing.T) {
+	store := NewSQLStore(nil, PostgreSQL)
+	assert.Equal(t, "SELECT data FROM leagues WHERE id = $1 AND slug = $2", store.rebind("SELECT data FROM leagues WHERE id = ? AND slug = ?"))
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	content, err := ioutil.ReadFile(filepath.Join("..", "testdata", name))
+	assert.Nil(t, err)
+	return content
+}
@@ -0,0 +1,26 @@
+package pandascore
+
+import "time"
+
+// Returns the player with the given ID or slug.
+func (c *Client) GetPlayer(idOrSlug string) (Player, error) {
+	player := new(Player)
+	_, err := c.RequestAllGames(resourcePath("players", idOrSlug)).Get(player)
+	return *player, err
+}
+
+// Player represents a single player, who may or may not currently be part of a team.
+type Player struct {
+	ID               int       `json:"id"`
+	Name             string    `json:"name"`
+	FirstName        string    `json:"first_name"`
+	LastName         string    `json:"last_name"`
+	Slug             string    `json:"slug"`
+	Hometown         string    `json:"hometown"`
+	Nationality      string    `json:"nationality"`
+	Role             string    `json:"role"`
+	ImageURL         string    `json:"image_url"`
+	CurrentTeam      Team      `json:"current_team"`
+	CurrentVideogame Videogame `json:"current_videogame"`
+	Modified         time.Time `json:"modified_at"`
+}
@@ -0,0 +1,27 @@
+package pandascore
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClient_GetPlayer(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/players/1794").
+		Reply(http.StatusOK).
+		File("testdata/csgo-player.json")
+
+	client := New()
+	result, err := client.GetPlayer("1794")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1794, result.ID)
+	assert.Equal(t, "NiKo", result.Name)
+	assert.Equal(t, "Kovač", result.LastName)
+	assert.Equal(t, "FaZe", result.CurrentTeam.Name)
+}
@@ -0,0 +1,107 @@
+package pandascore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Returned when a request can't be sent before its context's deadline without exceeding the rate limit.
+var ErrRateLimited = errors.New("PandaScore error: rate limit reached")
+
+// RateLimiter keeps the number of requests sent to the PandaScore API within a limit per interval (eg. 1000 requests
+// per hour), so the quota of an access token isn't exceeded. Requests can be sent in bursts up to the limit; after
+// that they are spread evenly over the interval.
+type RateLimiter struct {
+	mutex    sync.Mutex
+	limit    int
+	interval time.Duration
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+// Construct a new rate limiter that allows the given number of requests per interval. The limit and interval must be
+// larger than 0.
+func NewRateLimiter(limit int, interval time.Duration) *RateLimiter {
+	if limit <= 0 {
+		limit = 1
+	}
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &RateLimiter{limit: limit, interval: interval, tokens: float64(limit), now: time.Now}
+}
+
+// Limit the requests of the client to the given number per interval, eg. RateLimit(1000, time.Hour).
+func (c *Client) RateLimit(limit int, interval time.Duration) *Client {
+	c.rateLimiter = NewRateLimiter(limit, interval)
+	return c
+}
+
+// Wait until a request may be sent. Returns ErrRateLimited right away if that's only possible after the deadline of
+// the given context, or the error of the context if it's done while waiting.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mutex.Lock()
+	now := l.now()
+	l.refill(now)
+	l.tokens--
+	delay := time.Duration(0)
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens * float64(l.interval) / float64(l.limit))
+	}
+	if deadline, ok := ctx.Deadline(); ok && delay > time.Until(deadline) {
+		l.tokens++
+		l.mutex.Unlock()
+		return ErrRateLimited
+	}
+	l.mutex.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		l.tokens++
+		l.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Give back the quota of a request that was let through by Wait but wasn't sent after all.
+func (l *RateLimiter) refund() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.tokens++
+}
+
+// Returns the number of requests that can be sent right away.
+func (l *RateLimiter) Remaining() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.refill(l.now())
+	if l.tokens < 0 {
+		return 0
+	}
+	return int(l.tokens)
+}
+
+// Add the tokens earned since the last refill, up to the limit.
+func (l *RateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens += float64(now.Sub(l.last)) * float64(l.limit) / float64(l.interval)
+		if l.tokens > float64(l.limit) {
+			l.tokens = float64(l.limit)
+		}
+	}
+	l.last = now
+}
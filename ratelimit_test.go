@@ -0,0 +1,73 @@
+package pandascore
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func newTestRateLimiter(limit int, interval time.Duration) (*RateLimiter, *time.Time) {
+	now := time.Date(2020, time.April, 23, 13, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(limit, interval)
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter, now := newTestRateLimiter(2, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.Nil(t, limiter.Wait(ctx))
+	assert.Nil(t, limiter.Wait(ctx))
+	assert.Equal(t, 0, limiter.Remaining())
+	assert.Equal(t, ErrRateLimited, limiter.Wait(ctx), "Expected to fail right away when the wait exceeds the deadline")
+
+	*now = now.Add(30 * time.Minute)
+	assert.Equal(t, 1, limiter.Remaining(), "Expected tokens to be refilled over time")
+	assert.Nil(t, limiter.Wait(ctx))
+
+	*now = now.Add(10 * time.Hour)
+	assert.Equal(t, 2, limiter.Remaining(), "Expected tokens not to exceed the limit")
+}
+
+func TestRateLimiter_Wait_delays(t *testing.T) {
+	limiter, _ := newTestRateLimiter(1, 50*time.Millisecond)
+
+	assert.Nil(t, limiter.Wait(context.Background()))
+	started := time.Now()
+	assert.Nil(t, limiter.Wait(context.Background()))
+	assert.GreaterOrEqual(t, int64(time.Since(started)), int64(40*time.Millisecond))
+}
+
+func TestRateLimiter_Wait_canceled(t *testing.T) {
+	limiter, _ := newTestRateLimiter(1, time.Hour)
+	assert.Nil(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, limiter.Wait(ctx))
+	assert.Equal(t, 0.0, limiter.tokens, "Expected the reserved token to be given back")
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues.json")
+
+	client := New().RateLimit(1, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.Request(CSGO, "leagues").Context(ctx).Get(new([]League))
+	assert.Nil(t, err)
+
+	_, err = client.Request(CSGO, "leagues").Context(ctx).Get(new([]League))
+	assert.Equal(t, ErrRateLimited, err)
+}
@@ -1,7 +1,11 @@
 package pandascore
 
 import (
+	"context"
+	"net/url"
+	"reflect"
 	"strings"
+	"time"
 )
 
 const (
@@ -24,6 +28,7 @@ func (o Sorting) forField(field string) string {
 type Request struct {
 	client   *Client
 	game     Game
+	allGames bool
 	path     string
 	filter   map[string]string
 	search   map[string]string
@@ -31,6 +36,11 @@ type Request struct {
 	sort     []string
 	page     int
 	pageSize int
+	since    string
+	params   url.Values
+	ctx      context.Context
+	priority Priority
+	tag      string
 }
 
 // Adds a filter parameter to the request, where the given field must match the given value.
@@ -108,3 +118,111 @@ func (r *Request) PageSize(size int) *Request {
 	}
 	return r
 }
+
+// Only return elements modified after the given time. This is only supported by the incidents, additions, changes and
+// deletions endpoints; use Range on modified_at for other endpoints.
+//
+// Careful: the given time is always set to UTC (Zulu) so timezones are not take into account.
+func (r *Request) Since(since time.Time) *Request {
+	if !since.IsZero() {
+		r.since = since.UTC().Format(time.RFC3339)
+	}
+	return r
+}
+
+// Only return elements modified at or after the given time, sorted from least to most recently modified. Unlike Since,
+// this is supported by all list endpoints. The zero time is ignored.
+//
+// Careful: the given time is always set to UTC (Zulu) so timezones are not take into account.
+func (r *Request) ModifiedSince(since time.Time) *Request {
+	if since.IsZero() {
+		return r
+	}
+	r.sort = []string{Ascending.forField("modified_at")}
+	return r.Range("modified_at", since.UTC().Format(time.RFC3339), modifiedUntil)
+}
+
+// Upper bound of the modified_at range set by ModifiedSince, since PandaScore doesn't support open ranges.
+const modifiedUntil = "9999-12-31T23:59:59Z"
+
+// Fetches all elements modified at or after the given time into the slice pointed to by value, like GetAll with
+// ModifiedSince, and returns the checkpoint for the next call: the most recent modification time seen, or the given
+// time if nothing was modified. The elements must be structs with a Modified field, eg. []Match.
+func (r *Request) GetAllModifiedSince(since time.Time, value interface{}) (time.Time, error) {
+	if _, err := r.ModifiedSince(since).GetAll(value); err != nil {
+		return since, err
+	}
+
+	checkpoint := since
+	elements := reflect.Indirect(reflect.ValueOf(value))
+	if elements.Kind() != reflect.Slice {
+		return checkpoint, nil
+	}
+	for i := 0; i < elements.Len(); i++ {
+		element := reflect.Indirect(elements.Index(i))
+		if element.Kind() != reflect.Struct {
+			continue
+		}
+		field := element.FieldByName("Modified")
+		if !field.IsValid() {
+			continue
+		}
+		if modified, ok := field.Interface().(time.Time); ok && modified.After(checkpoint) {
+			checkpoint = modified
+		}
+	}
+	return checkpoint, nil
+}
+
+// Adds a raw query parameter to the request, for parameters that don't have a dedicated method. Values are added to
+// the ones set by other methods.
+func (r *Request) Param(name string, value ...string) *Request {
+	if r.params == nil {
+		r.params = make(url.Values)
+	}
+	if len(name) > 0 {
+		r.params[name] = append(r.params[name], value...)
+	}
+	return r
+}
+
+// Sets the context of the request, which cancels the request (and waiting for the rate limiter or scheduler) when it's
+// done. Its priority, if any, is used by the scheduler of the client.
+func (r *Request) Context(ctx context.Context) *Request {
+	r.ctx = ctx
+	return r
+}
+
+// Returns the context of the request, or the background context if none was set, along with the priority and tag of
+// the request if it has them.
+func (r *Request) context() context.Context {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if r.priority != 0 {
+		ctx = WithPriority(ctx, r.priority)
+	}
+	if len(r.tag) > 0 {
+		ctx = withTag(ctx, r.tag)
+	}
+	return ctx
+}
+
+// Returns the path of the resource with the given ID or slug, eg. matches/559177. The ID or slug is escaped, so it
+// can't point to a different endpoint.
+func resourcePath(resource string, idOrSlug string) string {
+	escaped := url.PathEscape(idOrSlug)
+	if escaped == "." || escaped == ".." {
+		escaped = strings.ReplaceAll(escaped, ".", "%2E")
+	}
+	return resource + "/" + escaped
+}
+
+// Returns the endpoint this request is executed against, without the base URL (eg. csgo/matches/running).
+func (r *Request) endpoint() string {
+	if r.allGames {
+		return r.path
+	}
+	return string(r.game) + "/" + r.path
+}
@@ -1,9 +1,13 @@
 package pandascore
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestRequest_Filter(t *testing.T) {
@@ -57,3 +61,69 @@ func TestRequest_PageSize(t *testing.T) {
 	request.PageSize(-1)
 	assert.Equal(t, 50, request.pageSize, "Expected page size to be 50 after it is set to a negative value")
 }
+
+func TestRequest_endpoint(t *testing.T) {
+	assert.Equal(t, "csgo/leagues", New().Request(CSGO, "leagues").endpoint())
+	assert.Equal(t, "leagues/4158/series", New().RequestAllGames("leagues/4158/series").endpoint())
+}
+
+func TestRequest_Since(t *testing.T) {
+	request := new(Request).Since(time.Date(2020, time.April, 23, 15, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	assert.Equal(t, "2020-04-23T13:00:00Z", request.since)
+
+	request.Since(time.Time{})
+	assert.Equal(t, "2020-04-23T13:00:00Z", request.since, "Expected zero time to be ignored")
+}
+
+func TestRequest_ModifiedSince(t *testing.T) {
+	request := new(Request).ModifiedSince(time.Date(2020, time.April, 23, 15, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	assert.Equal(t, "2020-04-23T13:00:00Z,9999-12-31T23:59:59Z", request.ranges["modified_at"])
+	assert.Equal(t, []string{"modified_at"}, request.sort)
+
+	request = new(Request).ModifiedSince(time.Time{})
+	assert.Empty(t, request.ranges, "Expected zero time to be ignored")
+	assert.Empty(t, request.sort)
+
+	since := time.Date(2020, time.April, 23, 13, 0, 0, 0, time.UTC)
+	request = new(Request).Sort("name", Ascending).ModifiedSince(since).ModifiedSince(since)
+	assert.Equal(t, []string{"modified_at"}, request.sort, "Expected the sort to be replaced instead of added to")
+}
+
+func TestRequest_GetAllModifiedSince(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		MatchParam("range[modified_at]", "2020-03-01T00:00:00Z,9999-12-31T23:59:59Z").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json")
+
+	since := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	leagues := new([]struct {
+		ID int `json:"id"`
+	})
+	checkpoint, err := New().Request(CSGO, "leagues").GetAllModifiedSince(since, leagues)
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+	assert.NotEmpty(t, *leagues)
+	assert.Equal(t, since, checkpoint, "Expected elements without a modification time to leave the checkpoint alone")
+}
+
+func TestRequest_Param(t *testing.T) {
+	request := new(Request).Param("videogame", "csgo").Param("videogame", "lol").Param("", "ignored")
+	assert.Equal(t, []string{"csgo", "lol"}, request.params["videogame"])
+	assert.Len(t, request.params, 1)
+}
+
+func TestRequest_Context(t *testing.T) {
+	assert.Equal(t, context.Background(), new(Request).context())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Equal(t, ctx, new(Request).Context(ctx).context())
+}
+
+func TestResourcePath(t *testing.T) {
+	assert.Equal(t, "matches/559177", resourcePath("matches", "559177"))
+	assert.Equal(t, "teams/faze", resourcePath("teams", "faze"))
+	assert.Equal(t, "matches/..%2Fleagues", resourcePath("matches", "../leagues"))
+	assert.Equal(t, "matches/%2E%2E", resourcePath("matches", ".."))
+}
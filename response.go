@@ -1,14 +1,32 @@
 package pandascore
 
+import "time"
+
 // Response as parsed from a PandaScore API. Important: this is not the actual response body but rather any other
 // information (headers, etc.) that might be useful for a caller to get.
 type Response struct {
 	CurrentPage    int
 	ResultsPerPage int
 	TotalResults   int
+
+	// Schema drift detected in the response body; only set if schema drift detection is enabled on the client
+	SchemaDrift []SchemaDrift
+
+	// Whether the response was served from the cache because the PandaScore API failed, along with its age; when
+	// getting all pages, the age of the oldest page
+	Stale bool
+	Age   time.Duration
 }
 
 // Returns true if there are more pages with more results.
 func (r *Response) HasMore() bool {
 	return r.TotalResults-(r.ResultsPerPage*r.CurrentPage) > 0
 }
+
+// Marks the response as stale if the given response of a previous page was, keeping the oldest age.
+func (r *Response) mergeStale(previous Response) {
+	r.Stale = r.Stale || previous.Stale
+	if previous.Age > r.Age {
+		r.Age = previous.Age
+	}
+}
@@ -0,0 +1,237 @@
+package pandascore
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// Requests that must be sent as soon as possible, eg. polling running matches
+	PriorityRealtime Priority = 1
+
+	// Requests someone is waiting for, eg. to render a page; the default
+	PriorityInteractive Priority = 2
+
+	// Requests that can wait, eg. backfilling historical data
+	PriorityBackground Priority = 3
+)
+
+// Priority of a request when a Scheduler decides which request may be sent next.
+type Priority byte
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityRealtime:
+		return "realtime"
+	case PriorityBackground:
+		return "background"
+	default:
+		return "interactive"
+	}
+}
+
+// Returns the given priority, or PriorityInteractive if it's not a valid priority.
+func (p Priority) orDefault() Priority {
+	if p < PriorityRealtime || p > PriorityBackground {
+		return PriorityInteractive
+	}
+	return p
+}
+
+type priorityKey struct{}
+
+// Returns a copy of the given context with the given priority, which is used by all requests with that context that
+// don't have a priority of their own.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// Returns the priority of the given context, or PriorityInteractive if it has none.
+func PriorityFromContext(ctx context.Context) Priority {
+	priority, _ := ctx.Value(priorityKey{}).(Priority)
+	return priority.orDefault()
+}
+
+// Sets the priority of the request, taking precedence over the priority of its context.
+func (r *Request) Priority(priority Priority) *Request {
+	r.priority = priority
+	return r
+}
+
+// Scheduler shares the quota of an access token (eg. 1000 requests per hour) between requests with different
+// priorities. Like a RateLimiter, it lets requests through right away as long as there's quota left and spreads them
+// evenly over the interval after that.
+//
+// Part of the quota can be reserved for a priority, so requests with a lower priority only get what's left after
+// the reservations of all higher priorities: with 1000 requests per hour of which 200 are reserved for realtime
+// requests, background requests have to wait as soon as less than 200 requests are left. Requests that have to wait
+// are queued and sent by priority, in the order they were made within a priority.
+type Scheduler struct {
+	mutex        sync.Mutex
+	bucket       *RateLimiter
+	reservations [PriorityBackground + 1]int
+	queues       [PriorityBackground + 1][]*waiter
+	timer        *time.Timer
+}
+
+// A request waiting in the queue of a scheduler until it may be sent.
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// Construct a new scheduler that allows the given number of requests per interval. The limit and interval must be
+// larger than 0.
+func NewScheduler(limit int, interval time.Duration) *Scheduler {
+	return &Scheduler{bucket: NewRateLimiter(limit, interval)}
+}
+
+// Route the requests of the client through the given scheduler. Use it instead of RateLimit, as both limit the
+// requests independently.
+func (c *Client) Scheduler(scheduler *Scheduler) *Client {
+	c.scheduler = scheduler
+	return c
+}
+
+// Reserves the given number of requests of the quota for the given priority.
+func (s *Scheduler) Reserve(priority Priority, requests int) *Scheduler {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if requests >= 0 {
+		s.reservations[priority.orDefault()] = requests
+	}
+	return s
+}
+
+// Wait until a request with the given priority may be sent. Returns ErrRateLimited right away if that's only possible
+// after the deadline of the given context, or the error of the context if it's done while waiting.
+func (s *Scheduler) Wait(ctx context.Context, priority Priority) error {
+	priority = priority.orDefault()
+
+	s.mutex.Lock()
+	if deadline, ok := ctx.Deadline(); ok && s.estimate(priority) > time.Until(deadline) {
+		s.mutex.Unlock()
+		return ErrRateLimited
+	}
+	current := &waiter{ready: make(chan struct{})}
+	s.queues[priority] = append(s.queues[priority], current)
+	s.dispatch()
+	s.mutex.Unlock()
+
+	select {
+	case <-current.ready:
+		return nil
+	case <-ctx.Done():
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if current.granted {
+			// The request was let through just now, so give its quota back for the next one
+			s.bucket.tokens++
+			s.dispatch()
+		} else {
+			s.remove(priority, current)
+			s.dispatch()
+		}
+		return ctx.Err()
+	}
+}
+
+// Give back the quota of a request that was let through by Wait but wasn't sent after all, for the next one in line.
+func (s *Scheduler) refund() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.bucket.tokens++
+	s.dispatch()
+}
+
+// Returns the number of requests with the given priority that are waiting in the queue.
+func (s *Scheduler) Queued(priority Priority) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.queues[priority.orDefault()])
+}
+
+// Returns the number of requests with the given priority that can be sent right away, taking the reservations of
+// higher priorities into account.
+func (s *Scheduler) Remaining(priority Priority) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.bucket.refill(s.bucket.now())
+	remaining := int(s.bucket.tokens) - s.reserved(priority.orDefault())
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Let the queued requests through that may be sent now, by priority, and schedule the next dispatch for the first
+// one that has to wait. Must be called with the mutex held.
+func (s *Scheduler) dispatch() {
+	s.bucket.refill(s.bucket.now())
+	for priority := PriorityRealtime; priority <= PriorityBackground; priority++ {
+		threshold := float64(1 + s.reserved(priority))
+		for len(s.queues[priority]) > 0 {
+			if s.bucket.tokens < threshold {
+				s.schedule(threshold - s.bucket.tokens)
+				return
+			}
+			next := s.queues[priority][0]
+			s.queues[priority] = s.queues[priority][1:]
+			s.bucket.tokens--
+			next.granted = true
+			close(next.ready)
+		}
+	}
+}
+
+// Dispatch again once the given number of requests has been added to the quota. Must be called with the mutex held.
+func (s *Scheduler) schedule(requests float64) {
+	delay := time.Duration(requests * float64(s.bucket.interval) / float64(s.bucket.limit))
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(delay, func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.dispatch()
+	})
+}
+
+// Returns the number of requests reserved for all priorities higher than the given one.
+func (s *Scheduler) reserved(priority Priority) int {
+	reserved := 0
+	for higher := PriorityRealtime; higher < priority; higher++ {
+		reserved += s.reservations[higher]
+	}
+	return reserved
+}
+
+// Returns how long a new request with the given priority has to wait, at best, given the requests that are queued
+// before it. Must be called with the mutex held.
+func (s *Scheduler) estimate(priority Priority) time.Duration {
+	s.bucket.refill(s.bucket.now())
+	needed := float64(1 + s.reserved(priority))
+	for higher := PriorityRealtime; higher <= priority; higher++ {
+		needed += float64(len(s.queues[higher]))
+	}
+	if s.bucket.tokens >= needed {
+		return 0
+	}
+	return time.Duration((needed - s.bucket.tokens) * float64(s.bucket.interval) / float64(s.bucket.limit))
+}
+
+// Removes the given waiter from the queue of the given priority. Must be called with the mutex held.
+func (s *Scheduler) remove(priority Priority, removed *waiter) {
+	queue := s.queues[priority]
+	for index, current := range queue {
+		if current == removed {
+			s.queues[priority] = append(queue[:index:index], queue[index+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package pandascore
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestScheduler_Wait(t *testing.T) {
+	scheduler := NewScheduler(2, time.Hour)
+
+	assert.Nil(t, scheduler.Wait(context.Background(), PriorityBackground))
+	assert.Nil(t, scheduler.Wait(context.Background(), PriorityRealtime))
+	assert.Equal(t, 0, scheduler.Remaining(PriorityRealtime))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Equal(t, ErrRateLimited, scheduler.Wait(ctx, PriorityRealtime))
+}
+
+func TestScheduler_Reserve(t *testing.T) {
+	scheduler := NewScheduler(10, time.Hour).Reserve(PriorityRealtime, 3).Reserve(PriorityInteractive, 2)
+	assert.Equal(t, 10, scheduler.Remaining(PriorityRealtime))
+	assert.Equal(t, 7, scheduler.Remaining(PriorityInteractive))
+	assert.Equal(t, 5, scheduler.Remaining(PriorityBackground))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, scheduler.Wait(ctx, PriorityBackground))
+	}
+	assert.Equal(t, ErrRateLimited, scheduler.Wait(ctx, PriorityBackground), "Expected background requests to leave the reservations alone")
+
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, scheduler.Wait(ctx, PriorityInteractive))
+	}
+	assert.Equal(t, ErrRateLimited, scheduler.Wait(ctx, PriorityInteractive))
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, scheduler.Wait(ctx, PriorityRealtime))
+	}
+	assert.Equal(t, 0, scheduler.Remaining(PriorityRealtime))
+}
+
+func TestScheduler_queue(t *testing.T) {
+	scheduler := NewScheduler(10, 4*time.Second)
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, scheduler.Wait(context.Background(), PriorityBackground))
+	}
+
+	var mutex sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	wait := func(priority Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, scheduler.Wait(context.Background(), priority))
+			mutex.Lock()
+			order = append(order, priority)
+			mutex.Unlock()
+		}()
+	}
+
+	// Queue the background requests first to make sure the realtime requests overtake them
+	wait(PriorityBackground)
+	wait(PriorityBackground)
+	assert.Eventually(t, func() bool { return scheduler.Queued(PriorityBackground) == 2 }, time.Second, time.Millisecond)
+	wait(PriorityRealtime)
+	wait(PriorityInteractive)
+	assert.Eventually(t, func() bool { return scheduler.Queued(PriorityInteractive) == 1 }, time.Second, time.Millisecond)
+	wg.Wait()
+
+	assert.Equal(t, []Priority{PriorityRealtime, PriorityInteractive, PriorityBackground, PriorityBackground}, order)
+}
+
+func TestScheduler_cancel(t *testing.T) {
+	scheduler := NewScheduler(1, time.Hour)
+	assert.Nil(t, scheduler.Wait(context.Background(), PriorityInteractive))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- scheduler.Wait(ctx, PriorityInteractive) }()
+	assert.Eventually(t, func() bool { return scheduler.Queued(PriorityInteractive) == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Equal(t, 0, scheduler.Queued(PriorityInteractive))
+}
+
+func TestClient_Scheduler(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json")
+
+	scheduler := NewScheduler(2, time.Hour).Reserve(PriorityRealtime, 1)
+	client := New().Scheduler(scheduler)
+
+	_, err := client.Request(CSGO, "leagues").Priority(PriorityBackground).Get(new([]League))
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+
+	ctx, cancel := context.WithTimeout(WithPriority(context.Background(), PriorityBackground), time.Second)
+	defer cancel()
+	_, err = client.Request(CSGO, "matches/running").Context(ctx).Get(new([]Match))
+	assert.Equal(t, ErrRateLimited, err, "Expected the last request to be reserved for realtime requests")
+	assert.Equal(t, 1, scheduler.Remaining(PriorityRealtime))
+}
+
+func TestRequest_Priority(t *testing.T) {
+	assert.Equal(t, PriorityInteractive, PriorityFromContext(new(Request).context()))
+
+	ctx := WithPriority(context.Background(), PriorityBackground)
+	assert.Equal(t, PriorityBackground, PriorityFromContext(new(Request).Context(ctx).context()))
+	assert.Equal(t, PriorityRealtime, PriorityFromContext(new(Request).Context(ctx).Priority(PriorityRealtime).context()))
+}
+
+func TestPriority_String(t *testing.T) {
+	assert.Equal(t, "realtime", PriorityRealtime.String())
+	assert.Equal(t, "interactive", PriorityInteractive.String())
+	assert.Equal(t, "background", PriorityBackground.String())
+}
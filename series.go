@@ -1,6 +1,16 @@
 package pandascore
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
+
+// Returns the series with the given ID or slug.
+func (c *Client) GetSeries(idOrSlug string) (Series, error) {
+	series := new(Series)
+	_, err := c.RequestAllGames(resourcePath("series", idOrSlug)).Get(series)
+	return *series, err
+}
 
 // Returns all currently ongoing series for the given game.
 func (c *Client) GetAllRunningSeries(game Game) ([]Series, error) {
@@ -9,11 +19,63 @@ func (c *Client) GetAllRunningSeries(game Game) ([]Series, error) {
 	return *series, err
 }
 
+// Returns all upcoming series for the given game.
+func (c *Client) GetAllUpcomingSeries(game Game) ([]Series, error) {
+	series := new([]Series)
+	_, err := c.Request(game, "series/upcoming").PageSize(100).GetAll(series)
+	return *series, err
+}
+
+// Returns all past series for the given game.
+func (c *Client) GetAllPastSeries(game Game) ([]Series, error) {
+	series := new([]Series)
+	_, err := c.Request(game, "series/past").PageSize(100).GetAll(series)
+	return *series, err
+}
+
+// Returns all series for the given game, regardless of whether they're past, running or upcoming.
+func (c *Client) GetAllSeries(game Game) ([]Series, error) {
+	series := new([]Series)
+	_, err := c.Request(game, "series").PageSize(100).GetAll(series)
+	return *series, err
+}
+
+// Returns all series for the given game that were modified at or after the given time, along with the checkpoint for
+// the next call. See GetAllMatchesModifiedSince.
+func (c *Client) GetAllSeriesModifiedSince(game Game, since time.Time) ([]Series, time.Time, error) {
+	series := new([]Series)
+	checkpoint, err := c.Request(game, "series").PageSize(100).GetAllModifiedSince(since, series)
+	return *series, checkpoint, err
+}
+
+// Returns all series of the league with the given ID, like GetLeagueSeries.
+func (c *Client) GetSeriesForLeague(leagueID int) ([]Series, error) {
+	return c.GetLeagueSeries(strconv.Itoa(leagueID))
+}
+
 // Series represents an instance of a league event.
 //
 // More information: https://developers.pandascore.co/doc/#section/Introduction/Events-hierarchy
 type Series struct {
-	ID       int       `json:"id"`
-	FullName string    `json:"full_name"`
-	Modified time.Time `json:"modified_at"`
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	FullName    string       `json:"full_name"`
+	Slug        string       `json:"slug"`
+	Description string       `json:"description"`
+	Season      string       `json:"season"`
+	Year        int          `json:"year"`
+	BeginsAt    time.Time    `json:"begin_at"`
+	EndsAt      time.Time    `json:"end_at"`
+	Modified    time.Time    `json:"modified_at"`
+	LeagueID    int          `json:"league_id"`
+	League      League       `json:"league"`
+	Tournaments []Tournament `json:"tournaments"`
+	WinnerID    int          `json:"winner_id"`
+	WinnerType  string       `json:"winner_type"`
+	Videogame   Videogame    `json:"videogame"`
+}
+
+// Returns true if the series has a winner, which is typically only the case once it's over.
+func (s *Series) HasWinner() bool {
+	return s.WinnerID != 0
 }
@@ -3,6 +3,7 @@ package pandascore
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/h2non/gock.v1"
@@ -53,3 +54,114 @@ func TestClient_GetAllRunningSeries_withPaging(t *testing.T) {
 	assert.Len(t, result, 4)
 	assert.Equal(t, []int{2522, 2528, 2523, 2529}, []int{result[0].ID, result[1].ID, result[2].ID, result[3].ID})
 }
+
+func TestClient_GetSeries(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/series/2522").
+		Reply(http.StatusOK).
+		File("testdata/csgo-serie.json")
+
+	client := New()
+	result, err := client.GetSeries("2522")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2522, result.ID)
+	assert.Equal(t, "ANZ Champs: Online Stage season 10 2020", result.FullName)
+}
+
+func TestClient_GetAllRunningSeries_fullyModeled(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/series/running").
+		Reply(http.StatusOK).
+		File("testdata/csgo-series-running.json")
+
+	client := New()
+	result, err := client.GetAllRunningSeries(CSGO)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+
+	series := result[0]
+	assert.Equal(t, "ANZ Champs: Online Stage", series.Name)
+	assert.Equal(t, "cs-go-esl-anz-champs-anz-champs-online-stage-10-2020", series.Slug)
+	assert.Equal(t, "10", series.Season)
+	assert.Equal(t, 2020, series.Year)
+	assert.Equal(t, time.Date(2020, time.March, 3, 7, 30, 0, 0, time.UTC), series.BeginsAt)
+	assert.True(t, series.EndsAt.IsZero())
+	assert.Equal(t, 4158, series.LeagueID)
+	assert.Equal(t, "ESL", series.League.Name)
+	assert.Equal(t, "CS:GO", series.Videogame.Name)
+	assert.False(t, series.HasWinner())
+	assert.Len(t, series.Tournaments, 3)
+	assert.Equal(t, "Stage 1", series.Tournaments[0].Name)
+	assert.Equal(t, 125874, series.Tournaments[0].WinnerID)
+	assert.Equal(t, "Team", series.Tournaments[0].WinnerType)
+}
+
+func TestClient_GetAllUpcomingSeries(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/series/upcoming").
+		MatchParam("page[size]", "100").
+		Reply(http.StatusOK).
+		File("testdata/csgo-series-running.json")
+
+	client := New()
+	result, err := client.GetAllUpcomingSeries(CSGO)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+}
+
+func TestClient_GetAllPastSeries(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/series/past").
+		MatchParam("page[size]", "100").
+		Reply(http.StatusOK).
+		File("testdata/csgo-series-running.json")
+
+	client := New()
+	result, err := client.GetAllPastSeries(CSGO)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+}
+
+func TestClient_GetAllSeries(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/series").
+		MatchParam("page[size]", "100").
+		Reply(http.StatusOK).
+		File("testdata/csgo-series-running.json")
+
+	client := New()
+	result, err := client.GetAllSeries(CSGO)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+}
+
+func TestClient_GetSeriesForLeague(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/leagues/4158/series").
+		Reply(http.StatusOK).
+		File("testdata/csgo-series-running.json")
+
+	client := New()
+	result, err := client.GetSeriesForLeague(4158)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, 4158, result[0].LeagueID)
+}
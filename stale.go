@@ -0,0 +1,170 @@
+package pandascore
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Maximum time between two attempts to refresh a stale response in the background
+const maxRefreshInterval = time.Minute
+
+// Keeps track of the stale responses that are being refreshed in the background, keyed by request URL.
+type revalidator struct {
+	mutex      sync.Mutex
+	refreshing map[string]bool
+	stop       chan struct{}
+	closed     bool
+	wg         sync.WaitGroup
+}
+
+// Stops refreshing stale responses in the background and waits for the refreshes in progress to give up. The client
+// can still be used afterwards, but stale responses are no longer refreshed.
+func (c *Client) Close() {
+	c.revalidator.mutex.Lock()
+	if !c.revalidator.closed {
+		c.revalidator.closed = true
+		close(c.revalidator.stopped())
+	}
+	c.revalidator.mutex.Unlock()
+
+	c.revalidator.wg.Wait()
+}
+
+// Returns the channel that's closed when the client is closed. Must be called with the mutex held.
+func (v *revalidator) stopped() chan struct{} {
+	if v.stop == nil {
+		v.stop = make(chan struct{})
+	}
+	return v.stop
+}
+
+// Serve the last cached response when the PandaScore API fails, as long as it's not older than the given age (or any
+// age if it's 0), instead of returning the error. Such responses are marked as stale.
+//
+// While a response is stale, identical requests get it right away without waiting for the API, and it's refreshed
+// in the background until the API has recovered. This requires a cache, see Cache.
+func (c *Client) ServeStale(maxAge time.Duration) *Client {
+	c.serveStale = true
+	c.staleMaxAge = maxAge
+	return c
+}
+
+// Returns the cached body and response for the given key, marked as stale, if they're not older than the given age
+// (or any age if it's 0).
+func (c *Client) cachedStale(key string, maxAge time.Duration) ([]byte, Response, bool) {
+	if c.cache == nil {
+		return nil, Response{}, false
+	}
+
+	entry, ok := c.cache.Get(key)
+	age := time.Since(entry.Stored)
+	if !ok || maxAge > 0 && age > maxAge {
+		return nil, Response{}, false
+	}
+	response := entry.Response
+	response.Stale = true
+	response.Age = age
+	return entry.Body, response, true
+}
+
+// Returns the stale response for the given key if it's being refreshed in the background, so requests don't wait for
+// the API while it's failing.
+func (c *Client) refreshing(key string) ([]byte, Response, bool) {
+	if !c.serveStale {
+		return nil, Response{}, false
+	}
+
+	c.revalidator.mutex.Lock()
+	refreshing := c.revalidator.refreshing[key]
+	c.revalidator.mutex.Unlock()
+	if !refreshing {
+		return nil, Response{}, false
+	}
+	return c.cachedStale(key, c.staleMaxAge)
+}
+
+// Returns the stale response for the given key if the given error means the API is failing, and starts refreshing it
+// in the background.
+func (c *Client) fallback(key string, group string, request *http.Request, err error) ([]byte, Response, bool) {
+	if !c.serveStale || !isFailure(err) && !errors.Is(err, ErrCircuitOpen) {
+		return nil, Response{}, false
+	}
+
+	body, response, ok := c.cachedStale(key, c.staleMaxAge)
+	if ok {
+		log.Printf("⚠ warning: PandaScore request failed, serving response from %s ago instead: %s", response.Age.Round(time.Second), err)
+		ctx := withTag(WithPriority(context.Background(), PriorityBackground), tagFromContext(request.Context()))
+		c.refresh(key, group, request.Clone(ctx))
+	}
+	return body, response, ok
+}
+
+// Refresh the response for the given key in the background, unless that's already happening or the client is closed,
+// by sending the request again with increasing intervals until the API has recovered or the cached response is too old
+// to be served.
+func (c *Client) refresh(key string, group string, request *http.Request) {
+	c.revalidator.mutex.Lock()
+	defer c.revalidator.mutex.Unlock()
+
+	if c.revalidator.refreshing == nil {
+		c.revalidator.refreshing = make(map[string]bool)
+	}
+	if c.revalidator.refreshing[key] || c.revalidator.closed {
+		return
+	}
+	c.revalidator.refreshing[key] = true
+	stop := c.revalidator.stopped()
+
+	// Closing the client also cancels the refresh that's in flight
+	ctx, cancel := context.WithCancel(request.Context())
+	request = request.Clone(ctx)
+
+	c.revalidator.wg.Add(1)
+	go func() {
+		defer c.revalidator.wg.Done()
+		defer func() {
+			c.revalidator.mutex.Lock()
+			delete(c.revalidator.refreshing, key)
+			c.revalidator.mutex.Unlock()
+		}()
+		defer cancel()
+		go func() {
+			select {
+			case <-stop:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		interval := c.staleRetryInterval
+		if interval <= 0 {
+			interval = time.Second
+		}
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return
+			}
+			_, _, err := c.inFlight.do(ctx, key, func() ([]byte, Response, error) {
+				return c.send(group, request)
+			})
+			if !isFailure(err) && !errors.Is(err, ErrCircuitOpen) {
+				return
+			}
+			if _, _, ok := c.cachedStale(key, c.staleMaxAge); !ok {
+				return
+			}
+			if interval *= 2; interval > maxRefreshInterval {
+				interval = maxRefreshInterval
+			}
+			timer.Reset(interval)
+		}
+	}()
+}
@@ -0,0 +1,183 @@
+package pandascore
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Server that serves the running CS:GO matches, or fails while failing is set, and counts the requests it receives.
+type flakyServer struct {
+	*httptest.Server
+	failing  int32
+	requests int32
+}
+
+func newFlakyServer(t *testing.T) *flakyServer {
+	server := &flakyServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&server.requests, 1)
+		if atomic.LoadInt32(&server.failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"Service unavailable"}`))
+			return
+		}
+		if r.URL.Path != "/csgo/matches/running" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := ioutil.ReadFile("testdata/csgo-matches-running.json")
+		assert.Nil(t, err)
+		_, _ = w.Write(content)
+	}))
+	return server
+}
+
+func (s *flakyServer) fail(failing bool) {
+	value := int32(0)
+	if failing {
+		value = 1
+	}
+	atomic.StoreInt32(&s.failing, value)
+}
+
+// Makes the cached entry for the given key older by the given duration.
+func ageCacheEntry(cache Cache, key string, age time.Duration) {
+	entry, _ := cache.Get(key)
+	entry.Stored = entry.Stored.Add(-age)
+	cache.Set(key, entry)
+}
+
+func TestClient_ServeStale(t *testing.T) {
+	server := newFlakyServer(t)
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	client := New().BaseURL(server.URL).Cache(cache, time.Minute).ServeStale(24 * time.Hour)
+	client.staleRetryInterval = 200 * time.Millisecond
+	defer client.Close()
+
+	response, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+	assert.False(t, response.Stale)
+
+	key := server.URL + "/csgo/matches/running"
+	ageCacheEntry(cache, key, time.Hour)
+	server.fail(true)
+
+	matches := new([]Match)
+	response, err = client.Request(CSGO, "matches/running").Get(matches)
+	assert.Nil(t, err, "Expected the cached response instead of the error")
+	assert.Len(t, *matches, 4)
+	assert.True(t, response.Stale)
+	assert.True(t, response.Age >= time.Hour)
+
+	response, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+	assert.True(t, response.Stale)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&server.requests), "Expected stale responses to be served without waiting for the API")
+
+	server.fail(false)
+	assert.Eventually(t, func() bool {
+		_, _, ok := client.cached(key)
+		return ok
+	}, 5*time.Second, 10*time.Millisecond, "Expected the response to be refreshed in the background")
+
+	response, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+	assert.False(t, response.Stale)
+}
+
+func TestClient_Close(t *testing.T) {
+	server := newFlakyServer(t)
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	client := New().BaseURL(server.URL).Cache(cache, time.Minute).ServeStale(0)
+	client.staleRetryInterval = time.Millisecond
+
+	_, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+	ageCacheEntry(cache, server.URL+"/csgo/matches/running", time.Hour)
+	server.fail(true)
+
+	response, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+	assert.True(t, response.Stale)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&server.requests) > 3 }, time.Second, time.Millisecond)
+
+	client.Close()
+	assert.Empty(t, client.revalidator.refreshing, "Expected the refresh to stop")
+	requests := atomic.LoadInt32(&server.requests)
+	_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+	client.Close()
+	assert.Equal(t, requests+1, atomic.LoadInt32(&server.requests), "Expected no refresh once the client is closed")
+}
+
+func TestClient_ServeStale_circuitOpen(t *testing.T) {
+	server := newFlakyServer(t)
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	client := New().BaseURL(server.URL).Cache(cache, time.Minute).ServeStale(time.Hour).
+		CircuitBreaker(NewCircuitBreaker(1, time.Minute))
+	defer client.Close()
+
+	_, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+	ageCacheEntry(cache, server.URL+"/csgo/matches/running", 2*time.Hour)
+	server.fail(true)
+
+	_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.EqualError(t, err, "PandaScore error: Service unavailable")
+	_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Equal(t, ErrCircuitOpen, err, "Expected the maximum age to apply while the circuit is open")
+}
+
+func TestClient_ServeStale_tooOld(t *testing.T) {
+	server := newFlakyServer(t)
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	client := New().BaseURL(server.URL).Cache(cache, time.Minute).ServeStale(time.Hour)
+
+	_, err := client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.Nil(t, err)
+
+	ageCacheEntry(cache, server.URL+"/csgo/matches/running", 2*time.Hour)
+	server.fail(true)
+
+	_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.EqualError(t, err, "PandaScore error: Service unavailable")
+}
+
+func TestClient_ServeStale_noFailure(t *testing.T) {
+	server := newFlakyServer(t)
+	defer server.Close()
+
+	client := New().BaseURL(server.URL).Cache(NewMemoryCache(10), time.Minute).ServeStale(0)
+
+	_, err := client.Request(CSGO, "matches/1").Get(new(Match))
+	assert.Equal(t, ErrNotFound, err, "Expected errors about the request itself to be returned as is")
+
+	server.fail(true)
+	_, err = client.Request(CSGO, "matches/running").Get(new([]Match))
+	assert.EqualError(t, err, "PandaScore error: Service unavailable", "Expected the error without a cached response")
+}
+
+func TestResponse_mergeStale(t *testing.T) {
+	response := Response{}
+	response.mergeStale(Response{Stale: true, Age: time.Minute})
+	assert.True(t, response.Stale)
+	assert.Equal(t, time.Minute, response.Age)
+
+	response = Response{Stale: true, Age: time.Hour}
+	response.mergeStale(Response{Stale: true, Age: time.Minute})
+	assert.Equal(t, time.Hour, response.Age, "Expected the age of the oldest page")
+}
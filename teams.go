@@ -0,0 +1,23 @@
+package pandascore
+
+import "time"
+
+// Returns the team with the given ID or slug.
+func (c *Client) GetTeam(idOrSlug string) (Team, error) {
+	team := new(Team)
+	_, err := c.RequestAllGames(resourcePath("teams", idOrSlug)).Get(team)
+	return *team, err
+}
+
+// Team represents a group of players that play matches together.
+type Team struct {
+	ID               int       `json:"id"`
+	Name             string    `json:"name"`
+	Acronym          string    `json:"acronym"`
+	Slug             string    `json:"slug"`
+	Location         string    `json:"location"`
+	LogoURL          string    `json:"image_url"`
+	Modified         time.Time `json:"modified_at"`
+	CurrentVideogame Videogame `json:"current_videogame"`
+	Players          []Player  `json:"players"`
+}
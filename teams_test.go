@@ -0,0 +1,28 @@
+package pandascore
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClient_GetTeam(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/teams/faze").
+		Reply(http.StatusOK).
+		File("testdata/csgo-team.json")
+
+	client := New()
+	result, err := client.GetTeam("faze")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3212, result.ID)
+	assert.Equal(t, "FaZe", result.Name)
+	assert.Equal(t, "CS:GO", result.CurrentVideogame.Name)
+	assert.Len(t, result.Players, 2)
+	assert.Equal(t, "NiKo", result.Players[0].Name)
+}
@@ -0,0 +1,48 @@
+package pandascore
+
+import "time"
+
+// Returns the tournament with the given ID or slug.
+func (c *Client) GetTournament(idOrSlug string) (Tournament, error) {
+	tournament := new(Tournament)
+	_, err := c.RequestAllGames(resourcePath("tournaments", idOrSlug)).Get(tournament)
+	return *tournament, err
+}
+
+// Returns all tournaments for the given game.
+func (c *Client) GetAllTournaments(game Game) ([]Tournament, error) {
+	tournaments := new([]Tournament)
+	_, err := c.Request(game, "tournaments").PageSize(100).GetAll(tournaments)
+	return *tournaments, err
+}
+
+// Returns all tournaments for the given game that were modified at or after the given time, along with the checkpoint for
+// the next call. See GetAllMatchesModifiedSince.
+func (c *Client) GetAllTournamentsModifiedSince(game Game, since time.Time) ([]Tournament, time.Time, error) {
+	tournaments := new([]Tournament)
+	checkpoint, err := c.Request(game, "tournaments").PageSize(100).GetAllModifiedSince(since, tournaments)
+	return *tournaments, checkpoint, err
+}
+
+// Tournament represents a stage of a series (eg. group stage or playoffs), which groups a number of matches.
+//
+// More information: https://developers.pandascore.co/doc/#section/Introduction/Events-hierarchy
+type Tournament struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	Slug          string    `json:"slug"`
+	BeginsAt      time.Time `json:"begin_at"`
+	EndsAt        time.Time `json:"end_at"`
+	Modified      time.Time `json:"modified_at"`
+	LeagueID      int       `json:"league_id"`
+	SeriesID      int       `json:"serie_id"`
+	LiveSupported bool      `json:"live_supported"`
+	Prizepool     string    `json:"prizepool"`
+	WinnerID      int       `json:"winner_id"`
+	WinnerType    string    `json:"winner_type"`
+	League        League    `json:"league"`
+	Series        Series    `json:"serie"`
+	Videogame     Videogame `json:"videogame"`
+	Teams         []Team    `json:"teams"`
+	Matches       []Match   `json:"matches"`
+}
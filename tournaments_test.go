@@ -0,0 +1,60 @@
+package pandascore
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClient_GetTournament(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/tournaments/3770").
+		Reply(http.StatusOK).
+		File("testdata/csgo-tournament.json")
+
+	client := New()
+	result, err := client.GetTournament("3770")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3770, result.ID)
+	assert.Equal(t, "Stage 1", result.Name)
+	assert.Equal(t, time.Date(2020, time.March, 14, 6, 32, 0, 0, time.UTC), result.EndsAt)
+	assert.Equal(t, 125874, result.WinnerID)
+	assert.Equal(t, "ESL", result.League.Name)
+	assert.Equal(t, 2522, result.Series.ID)
+}
+
+func TestClient_GetTournament_notFound(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/tournaments/does-not-exist").
+		Reply(http.StatusNotFound).
+		File("testdata/error-not-found.json")
+
+	client := New()
+	_, err := client.GetTournament("does-not-exist")
+
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestClient_GetAllTournaments(t *testing.T) {
+	defer gock.Off()
+	defer assert.True(t, gock.IsDone())
+
+	gock.New("https://api.pandascore.co/csgo/tournaments").
+		MatchParam("page[size]", "100").
+		Reply(http.StatusOK).
+		File("testdata/csgo-tournaments.json")
+
+	client := New()
+	result, err := client.GetAllTournaments(CSGO)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+}
@@ -0,0 +1,270 @@
+package pandascore
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default number of requests per hour of a PandaScore access token, used to forecast when the quota runs out
+const DefaultHourlyQuota = 1000
+
+// Endpoints are counted with the IDs in their path replaced by this placeholder, eg. csgo/matches/:id
+const idPlaceholder = ":id"
+
+const (
+	// Maximum number of tags and endpoints counted separately; requests with any other tag or to any other endpoint
+	// are counted together under UsageOther, so callers can't grow the usage counters without limit
+	MaxUsageTags      = 100
+	MaxUsageEndpoints = 500
+
+	// Tag and endpoint of the requests that are counted together once MaxUsageTags or MaxUsageEndpoints is reached
+	UsageOther = "other"
+)
+
+type tagKey struct{}
+
+// Tags the request so its usage of the quota is counted separately, eg. per feature. See Client.Usage.
+func (r *Request) Tag(tag string) *Request {
+	r.tag = tag
+	return r
+}
+
+// Usage of the PandaScore API by the requests of a client since its usage was last reset.
+type Usage struct {
+	Since    time.Time
+	Counters []UsageCounters
+
+	// Number of requests left in the quota of the current hour, as reported by the PandaScore API or estimated from
+	// the hourly quota of the client, and when they will run out at the rate of the last hour; zero if they won't
+	QuotaRemaining int
+	QuotaExhausted time.Time
+}
+
+// UsageCounters counts the requests with a tag to an endpoint, eg. dashboard and csgo/matches/running.
+type UsageCounters struct {
+	Tag      string
+	Endpoint string
+
+	// Requests sent to the PandaScore API, which count towards the quota. A request shared by identical requests with
+	// other tags is only counted for the tag of the one that was sent.
+	Requests int
+
+	// Pages of results returned, including the ones served from the cache or shared with identical requests
+	Pages int
+
+	// Bytes of the response bodies received from the PandaScore API
+	Bytes int64
+
+	// Requests that failed
+	Errors int
+}
+
+// Counts the usage of the PandaScore API by the requests of a client.
+type usage struct {
+	mutex     sync.Mutex
+	since     time.Time
+	counters  map[[2]string]*UsageCounters
+	tags      map[string]bool
+	endpoints map[string]bool
+	sent      []time.Time
+	remaining int
+	reported  time.Time
+	now       func() time.Time
+}
+
+// Sets the number of requests per hour of the access token, used to forecast when the quota runs out in case the
+// PandaScore API doesn't report the remaining requests itself. Defaults to DefaultHourlyQuota.
+func (c *Client) HourlyQuota(requests int) *Client {
+	if requests > 0 {
+		c.hourlyQuota = requests
+	}
+	return c
+}
+
+// Returns the usage of the PandaScore API by the requests of the client, per tag and endpoint, since the client was
+// created or its usage was last reset.
+func (c *Client) Usage() Usage {
+	c.usage.mutex.Lock()
+	defer c.usage.mutex.Unlock()
+
+	now := c.usage.clock()
+	c.usage.prune(now)
+	result := Usage{Since: c.usage.since, Counters: make([]UsageCounters, 0, len(c.usage.counters))}
+	for _, counters := range c.usage.counters {
+		result.Counters = append(result.Counters, *counters)
+	}
+	sort.Slice(result.Counters, func(i, j int) bool {
+		if result.Counters[i].Tag != result.Counters[j].Tag {
+			return result.Counters[i].Tag < result.Counters[j].Tag
+		}
+		return result.Counters[i].Endpoint < result.Counters[j].Endpoint
+	})
+
+	result.QuotaRemaining = c.hourlyQuota - len(c.usage.sent)
+	if !c.usage.reported.IsZero() && now.Sub(c.usage.reported) < time.Hour {
+		result.QuotaRemaining = c.usage.remaining
+	}
+	if result.QuotaRemaining < 0 {
+		result.QuotaRemaining = 0
+	}
+	result.QuotaExhausted = c.usage.forecast(now, result.QuotaRemaining)
+	return result
+}
+
+// Resets the usage counters of the client.
+func (c *Client) ResetUsage() {
+	c.usage.mutex.Lock()
+	defer c.usage.mutex.Unlock()
+
+	c.usage.since = c.usage.clock()
+	c.usage.counters = nil
+	c.usage.tags = nil
+	c.usage.endpoints = nil
+}
+
+// Returns the counters for the given tag and endpoint, or for UsageOther once too many different tags or endpoints
+// were counted. Must be called with the mutex held.
+func (u *usage) counter(tag string, endpoint string) *UsageCounters {
+	if u.counters == nil {
+		u.counters = make(map[[2]string]*UsageCounters)
+		u.tags = make(map[string]bool)
+		u.endpoints = make(map[string]bool)
+	}
+	key := [2]string{limit(u.tags, tag, MaxUsageTags), limit(u.endpoints, usageEndpoint(endpoint), MaxUsageEndpoints)}
+	counters, ok := u.counters[key]
+	if !ok {
+		counters = &UsageCounters{Tag: key[0], Endpoint: key[1]}
+		u.counters[key] = counters
+	}
+	return counters
+}
+
+// Returns the given value and remembers it, or UsageOther if the given number of other values were seen already.
+func limit(seen map[string]bool, value string, max int) string {
+	if !seen[value] {
+		if len(seen) >= max {
+			return UsageOther
+		}
+		seen[value] = true
+	}
+	return value
+}
+
+// Count a page of results returned to a caller, or an error.
+func (u *usage) page(tag string, endpoint string, err error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	counters := u.counter(tag, endpoint)
+	if err != nil {
+		counters.Errors++
+	} else {
+		counters.Pages++
+	}
+}
+
+// Count a request sent to the PandaScore API along with its response, which is nil if it wasn't received.
+func (u *usage) request(tag string, endpoint string, response *http.Response, bytes int) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	counters := u.counter(tag, endpoint)
+	counters.Requests++
+	counters.Bytes += int64(bytes)
+
+	now := u.clock()
+	u.sent = append(u.sent, now)
+	u.prune(now)
+	if response != nil {
+		if remaining, err := strconv.Atoi(response.Header.Get("X-Rate-Limit-Remaining")); err == nil {
+			u.remaining = remaining
+			u.reported = now
+		}
+	}
+}
+
+// Returns when the given number of remaining requests runs out at the rate of the last hour, or zero if they won't: if
+// there were no requests, if the rate is within the remaining requests per hour, or if the oldest request counting
+// towards the quota drops out of the hour before then. Must be called with the mutex held.
+func (u *usage) forecast(now time.Time, remaining int) time.Time {
+	if len(u.sent) == 0 {
+		return time.Time{}
+	}
+	if remaining == 0 {
+		return now
+	}
+
+	// Requests are only spread over the time since the first one, so the rate isn't underestimated right after
+	// starting; but at least a minute, so a single request doesn't mean the quota runs out right away
+	window := now.Sub(u.sent[0])
+	if window < time.Minute {
+		window = time.Minute
+	}
+	rate := float64(len(u.sent)) / float64(window)
+	if rate*float64(time.Hour) <= float64(remaining) {
+		return time.Time{}
+	}
+	exhausted := now.Add(time.Duration(float64(remaining) / rate))
+	if exhausted.After(u.sent[0].Add(time.Hour)) {
+		return time.Time{}
+	}
+	return exhausted
+}
+
+// Forget about the requests sent more than an hour ago. Must be called with the mutex held.
+func (u *usage) prune(now time.Time) {
+	index := sort.Search(len(u.sent), func(i int) bool { return now.Sub(u.sent[i]) < time.Hour })
+	u.sent = u.sent[index:]
+}
+
+func (u *usage) clock() time.Time {
+	if u.now == nil {
+		return time.Now()
+	}
+	return u.now()
+}
+
+// Collections of the PandaScore API whose resources are addressed by ID or slug, eg. teams/astralis
+var usageCollections = map[string]bool{
+	"games": true, "leagues": true, "lives": true, "matches": true, "players": true, "series": true, "teams": true,
+	"tournaments": true, "videogames": true,
+}
+
+// Endpoints of a collection that follow it in the path like an ID or slug would, eg. matches/running
+var usageSubEndpoints = map[string]bool{"past": true, "running": true, "upcoming": true}
+
+// Returns the given endpoint with the IDs and slugs in its path replaced, so requests for different resources of the
+// same kind are counted together.
+func usageEndpoint(endpoint string) string {
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	for index := len(segments) - 1; index >= 0; index-- {
+		segment := segments[index]
+		if _, err := strconv.Atoi(segment); err == nil {
+			segments[index] = idPlaceholder
+		} else if index > 0 && usageCollections[segments[index-1]] && !usageSubEndpoints[segment] {
+			segments[index] = idPlaceholder
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Returns the endpoint the given request is sent to, without the base URL of the client.
+func (c *Client) endpointOf(request *http.Request) string {
+	return strings.TrimPrefix(request.URL.Path, c.baseURL.Path)
+}
+
+// Returns a copy of the given context with the given tag, so the request sent with it is counted for that tag.
+func withTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, tagKey{}, tag)
+}
+
+// Returns the tag of the given context, or an empty tag if it has none.
+func tagFromContext(ctx context.Context) string {
+	tag, _ := ctx.Value(tagKey{}).(string)
+	return tag
+}
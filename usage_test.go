@@ -0,0 +1,127 @@
+package pandascore
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClient_Usage(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.pandascore.co/csgo/matches/running").
+		Reply(http.StatusOK).
+		File("testdata/csgo-matches-running.json")
+	gock.New("https://api.pandascore.co/csgo/matches/1").
+		Reply(http.StatusNotFound)
+	gock.New("https://api.pandascore.co/csgo/leagues").
+		Reply(http.StatusOK).
+		File("testdata/csgo-leagues-esl.json")
+
+	client := New().Cache(NewMemoryCache(10), time.Minute)
+	for i := 0; i < 2; i++ {
+		_, err := client.Request(CSGO, "matches/running").Tag("dashboard").Get(new([]Match))
+		assert.Nil(t, err)
+	}
+	_, err := client.Request(CSGO, "matches/1").Tag("backfill").Get(new(Match))
+	assert.Equal(t, ErrNotFound, err)
+	_, err = client.Request(CSGO, "leagues").Get(new([]League))
+	assert.Nil(t, err)
+	assert.True(t, gock.IsDone())
+
+	usage := client.Usage()
+	assert.Equal(t, []UsageCounters{
+		{Tag: "", Endpoint: "csgo/leagues", Requests: 1, Pages: 1, Bytes: 37755},
+		{Tag: "backfill", Endpoint: "csgo/matches/:id", Requests: 1, Errors: 1},
+		{Tag: "dashboard", Endpoint: "csgo/matches/running", Requests: 1, Pages: 2, Bytes: 16966},
+	}, usage.Counters, "Expected the cached page to be counted without a request")
+	assert.Equal(t, DefaultHourlyQuota-3, usage.QuotaRemaining)
+	assert.False(t, usage.Since.IsZero())
+
+	client.ResetUsage()
+	usage = client.Usage()
+	assert.Empty(t, usage.Counters)
+	assert.Equal(t, DefaultHourlyQuota-3, usage.QuotaRemaining, "Expected the quota not to be reset along with the counters")
+}
+
+func TestClient_Usage_forecast(t *testing.T) {
+	now := time.Date(2020, 4, 4, 12, 0, 0, 0, time.UTC)
+	client := New().HourlyQuota(100)
+	client.usage.now = func() time.Time { return now }
+
+	usage := client.Usage()
+	assert.Equal(t, 100, usage.QuotaRemaining)
+	assert.True(t, usage.QuotaExhausted.IsZero(), "Expected no forecast without requests")
+
+	// One request per minute for 10 minutes
+	for i := 0; i < 10; i++ {
+		client.usage.request("", "csgo/leagues", nil, 0)
+		now = now.Add(time.Minute)
+	}
+	usage = client.Usage()
+	assert.Equal(t, 90, usage.QuotaRemaining)
+	assert.True(t, usage.QuotaExhausted.IsZero(), "Expected 60 requests per hour to stay within the quota")
+
+	response := &http.Response{Header: http.Header{"X-Rate-Limit-Remaining": []string{"33"}}}
+	client.usage.request("", "csgo/leagues", response, 0)
+	usage = client.Usage()
+	assert.Equal(t, 33, usage.QuotaRemaining, "Expected the remaining requests reported by the API to be used")
+	assert.WithinDuration(t, now.Add(30*time.Minute), usage.QuotaExhausted, time.Second, "Expected 11 requests in 10 minutes")
+
+	now = now.Add(2 * time.Hour)
+	usage = client.Usage()
+	assert.Equal(t, 100, usage.QuotaRemaining, "Expected requests of more than an hour ago not to count")
+	assert.True(t, usage.QuotaExhausted.IsZero())
+}
+
+func TestClient_Usage_forecastWindow(t *testing.T) {
+	now := time.Date(2020, 4, 4, 12, 0, 0, 0, time.UTC)
+	client := New().HourlyQuota(100)
+	client.usage.now = func() time.Time { return now }
+
+	// 72 requests per hour, but the remaining 40 would only run out after the first requests have left the hour
+	for i := 0; i < 60; i++ {
+		client.usage.request("", "csgo/leagues", nil, 0)
+		now = now.Add(50 * time.Second)
+	}
+	usage := client.Usage()
+	assert.Equal(t, 40, usage.QuotaRemaining)
+	assert.True(t, usage.QuotaExhausted.IsZero())
+}
+
+func TestUsageEndpoint(t *testing.T) {
+	assert.Equal(t, "csgo/matches/running", usageEndpoint("csgo/matches/running"))
+	assert.Equal(t, "csgo/matches/:id", usageEndpoint("csgo/matches/1234"))
+	assert.Equal(t, "teams/:id/matches", usageEndpoint("/teams/42/matches"))
+	assert.Equal(t, "teams/:id/matches", usageEndpoint("/teams/astralis/matches"))
+	assert.Equal(t, "csgo/teams/:id", usageEndpoint("csgo/teams/astralis"))
+	assert.Equal(t, "leagues/:id/series/running", usageEndpoint("leagues/esl-one/series/running"))
+	assert.Equal(t, "matches/past", usageEndpoint("matches/past"))
+}
+
+func TestClient_Usage_limit(t *testing.T) {
+	client := New()
+	for i := 0; i < MaxUsageEndpoints+10; i++ {
+		client.usage.page("tag-0", fmt.Sprintf("csgo/endpoint-%d", i), nil)
+	}
+	for i := 0; i < MaxUsageTags+10; i++ {
+		client.usage.page(fmt.Sprintf("tag-%d", i), "csgo/endpoint-0", nil)
+	}
+
+	tags := make(map[string]bool)
+	endpoints := make(map[string]bool)
+	others := 0
+	for _, counters := range client.Usage().Counters {
+		tags[counters.Tag] = true
+		endpoints[counters.Endpoint] = true
+		if counters.Tag == UsageOther || counters.Endpoint == UsageOther {
+			others += counters.Pages
+		}
+	}
+	assert.Len(t, tags, MaxUsageTags+1)
+	assert.Len(t, endpoints, MaxUsageEndpoints+1)
+	assert.Equal(t, 20, others, "Expected the pages beyond the limits to be counted as other")
+}
@@ -0,0 +1,242 @@
+package pandascore
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// A match that wasn't running before is now running
+	MatchStarted MatchEventType = "match_started"
+
+	// The score of at least one of the opponents of a match changed
+	ScoreChanged MatchEventType = "score_changed"
+
+	// One of the games of a match is finished; the game is available on the event
+	GameFinished MatchEventType = "game_finished"
+
+	// A match is over and its results are final
+	MatchFinished MatchEventType = "match_finished"
+
+	// The time a match is scheduled at changed
+	MatchRescheduled MatchEventType = "match_rescheduled"
+
+	// A match was canceled
+	MatchCanceled MatchEventType = "match_canceled"
+
+	// Default time between two polls of the watcher
+	DefaultWatchInterval = 30 * time.Second
+)
+
+// MatchEventType describes what changed about a match between two polls of a Watcher.
+type MatchEventType string
+
+// MatchEvent represents a single change to a match detected by a Watcher. Previous is the match as it was during the
+// previous poll; Game is only set for GameFinished events.
+type MatchEvent struct {
+	Type     MatchEventType
+	Time     time.Time
+	Match    Match
+	Previous Match
+	Game     *MatchGame
+}
+
+// Clock is used by the watcher to tell time, so it can be replaced in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Watcher polls a number of match queries at an interval and emits an event for every change between two polls. It's
+// an alternative to live data for matches or games without live support.
+type Watcher struct {
+	client   *Client
+	queries  []func() ([]Match, error)
+	interval time.Duration
+	clock    Clock
+}
+
+// Construct a new watcher that watches the running matches of the given games. More queries can be added with Query.
+func (c *Client) Watcher(games ...Game) *Watcher {
+	w := &Watcher{client: c, interval: DefaultWatchInterval, clock: realClock{}}
+	for _, game := range games {
+		game := game
+		w.Query(func() ([]Match, error) { return c.GetAllRunningMatches(game) })
+	}
+	return w
+}
+
+// Adds a query to the watcher, eg. a call to GetAllUpcomingMatches. The results of all queries are combined into a
+// single snapshot every poll.
+func (w *Watcher) Query(query func() ([]Match, error)) *Watcher {
+	w.queries = append(w.queries, query)
+	return w
+}
+
+// Sets the time between two polls. The interval must be larger than 0.
+func (w *Watcher) Interval(interval time.Duration) *Watcher {
+	if interval > 0 {
+		w.interval = interval
+	}
+	return w
+}
+
+// Sets the clock used to wait between polls and to timestamp events.
+func (w *Watcher) Clock(clock Clock) *Watcher {
+	w.clock = clock
+	return w
+}
+
+// Start watching until the given context is done. The first poll only establishes which matches are being watched;
+// events are emitted for changes detected in later polls. The returned channel is closed when the watcher stops.
+//
+// Matches that are no longer returned by any query are fetched one last time, so the watcher can tell whether they
+// were finished or canceled.
+func (w *Watcher) Run(ctx context.Context) <-chan MatchEvent {
+	events := make(chan MatchEvent)
+
+	go func() {
+		defer close(events)
+
+		var previous map[int]Match
+		for {
+			current, err := w.snapshot()
+			if err != nil {
+				log.Printf("failed to poll PandaScore matches: %s", err)
+			} else {
+				if previous != nil {
+					for _, event := range w.diff(ctx, previous, current) {
+						select {
+						case events <- event:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+				previous = current
+			}
+
+			select {
+			case <-w.clock.After(w.interval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// Returns the combined results of all queries keyed by match ID.
+func (w *Watcher) snapshot() (map[int]Match, error) {
+	snapshot := make(map[int]Match)
+	for _, query := range w.queries {
+		matches, err := query()
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			snapshot[match.ID] = match
+		}
+	}
+	return snapshot, nil
+}
+
+// Returns the events for all differences between the two snapshots. Matches that are no longer in the current snapshot
+// are fetched with the given context.
+func (w *Watcher) diff(ctx context.Context, previous map[int]Match, current map[int]Match) []MatchEvent {
+	now := w.clock.Now()
+	var events []MatchEvent
+
+	for _, id := range sortedMatchIDs(current) {
+		match := current[id]
+		before, ok := previous[id]
+		if !ok {
+			if match.Status == "running" {
+				events = append(events, MatchEvent{Type: MatchStarted, Time: now, Match: match})
+			}
+			continue
+		}
+		events = append(events, diffMatch(now, before, match)...)
+	}
+
+	for _, id := range sortedMatchIDs(previous) {
+		before := previous[id]
+		if _, ok := current[id]; ok {
+			continue
+		}
+		match := new(Match)
+		_, err := w.client.RequestAllGames(resourcePath("matches", strconv.Itoa(id))).Context(ctx).Get(match)
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			log.Printf("failed to fetch PandaScore match %d that is no longer being watched: %s", id, err)
+			continue
+		}
+		events = append(events, diffMatch(now, before, *match)...)
+	}
+
+	return events
+}
+
+func sortedMatchIDs(matches map[int]Match) []int {
+	ids := make([]int, 0, len(matches))
+	for id := range matches {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Returns the events for all differences between two versions of the same match.
+func diffMatch(now time.Time, before Match, after Match) []MatchEvent {
+	var events []MatchEvent
+	event := func(eventType MatchEventType, game *MatchGame) {
+		events = append(events, MatchEvent{Type: eventType, Time: now, Match: after, Previous: before, Game: game})
+	}
+
+	if after.Status == "running" && before.Status != "running" {
+		event(MatchStarted, nil)
+	}
+	if !before.ScheduledAt.IsZero() && !after.ScheduledAt.Equal(before.ScheduledAt) {
+		event(MatchRescheduled, nil)
+	}
+
+	finishedBefore := make(map[int]bool)
+	for _, game := range before.Games {
+		finishedBefore[game.ID] = game.Finished
+	}
+	for i := range after.Games {
+		if after.Games[i].Finished && !finishedBefore[after.Games[i].ID] {
+			game := after.Games[i]
+			event(GameFinished, &game)
+		}
+	}
+
+	for _, result := range after.Results {
+		if result.Score != before.ScoreOf(result.OpponentID()) {
+			event(ScoreChanged, nil)
+			break
+		}
+	}
+
+	if after.Status != before.Status {
+		switch after.Status {
+		case "finished":
+			event(MatchFinished, nil)
+		case "canceled":
+			event(MatchCanceled, nil)
+		}
+	}
+
+	return events
+}
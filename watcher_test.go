@@ -0,0 +1,175 @@
+package pandascore
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// Clock that only moves on when the test sends a tick.
+type fakeClock struct {
+	now   time.Time
+	ticks chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time                       { return c.now }
+func (c *fakeClock) After(time.Duration) <-chan time.Time { return c.ticks }
+
+// Returns a query that returns the given snapshots one after the other and keeps returning the last one.
+func scriptedQuery(snapshots ...[]Match) func() ([]Match, error) {
+	poll := 0
+	return func() ([]Match, error) {
+		snapshot := snapshots[poll]
+		if poll < len(snapshots)-1 {
+			poll++
+		}
+		return snapshot, nil
+	}
+}
+
+func receiveEvents(t *testing.T, events <-chan MatchEvent, count int) []MatchEvent {
+	var received []MatchEvent
+	for len(received) < count {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %d events but only received %d", count, len(received))
+		}
+	}
+	return received
+}
+
+func eventTypes(events []MatchEvent) []MatchEventType {
+	var types []MatchEventType
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestClient_Watcher(t *testing.T) {
+	result := New().Watcher(CSGO, LoL)
+
+	assert.Len(t, result.queries, 2)
+	assert.Equal(t, DefaultWatchInterval, result.interval)
+
+	result.Interval(-1)
+	assert.Equal(t, DefaultWatchInterval, result.interval, "Expected invalid interval to be ignored")
+}
+
+func TestWatcher_Run(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.pandascore.co/matches/2").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"id":      2,
+			"status":  "finished",
+			"results": []map[string]int{{"team_id": 10, "score": 2}, {"team_id": 11, "score": 0}},
+			"games": []map[string]interface{}{
+				{"id": 21, "position": 1, "finished": true},
+				{"id": 22, "position": 2, "finished": true},
+			},
+		})
+
+	scheduled := time.Date(2020, time.April, 23, 10, 0, 0, 0, time.UTC)
+	a := Match{ID: 1, Status: "not_started", ScheduledAt: scheduled}
+	b := Match{
+		ID:      2,
+		Status:  "running",
+		Results: []MatchResult{{TeamID: 10, Score: 0}, {TeamID: 11, Score: 0}},
+		Games:   []MatchGame{{ID: 21, Position: 1, Status: "running"}, {ID: 22, Position: 2}},
+	}
+
+	rescheduledA := a
+	rescheduledA.ScheduledAt = scheduled.Add(time.Hour)
+	startedA := rescheduledA
+	startedA.Status = "running"
+
+	scoredB := b
+	scoredB.Results = []MatchResult{{TeamID: 10, Score: 1}, {TeamID: 11, Score: 0}}
+	scoredB.Games = []MatchGame{{ID: 21, Position: 1, Finished: true}, {ID: 22, Position: 2, Status: "running"}}
+
+	c := Match{ID: 3, Status: "running"}
+
+	clock := &fakeClock{now: scheduled, ticks: make(chan time.Time)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := New().
+		Watcher().
+		Query(scriptedQuery([]Match{a, b}, []Match{rescheduledA, scoredB, c}, []Match{startedA, c})).
+		Clock(clock).
+		Run(ctx)
+
+	clock.ticks <- scheduled
+	second := receiveEvents(t, events, 4)
+	assert.Equal(t, []MatchEventType{MatchRescheduled, GameFinished, ScoreChanged, MatchStarted}, eventTypes(second))
+	assert.Equal(t, scheduled, second[0].Time)
+	assert.Equal(t, a, second[0].Previous)
+	assert.Equal(t, rescheduledA, second[0].Match)
+	assert.Equal(t, 21, second[1].Game.ID)
+	assert.Equal(t, 3, second[3].Match.ID)
+
+	clock.ticks <- scheduled
+	third := receiveEvents(t, events, 4)
+	assert.Equal(t, []MatchEventType{MatchStarted, GameFinished, ScoreChanged, MatchFinished}, eventTypes(third))
+	assert.Equal(t, 1, third[0].Match.ID)
+	assert.Equal(t, 22, third[1].Game.ID, "Expected match that is no longer watched to be fetched one last time")
+	assert.Equal(t, 2, third[3].Match.ScoreOf(10))
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatcher_diff_cancelled(t *testing.T) {
+	release := make(chan struct{})
+	var requests int32
+	server := newBlockingServer(t, release, &requests)
+	defer server.Close()
+	defer close(release)
+
+	w := New().BaseURL(server.URL).Watcher()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan []MatchEvent)
+	go func() { done <- w.diff(ctx, map[int]Match{1: {ID: 1}, 2: {ID: 2}}, map[int]Match{}) }()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case events := <-done:
+		assert.Empty(t, events)
+	case <-time.After(time.Second):
+		t.Fatal("Expected the match that is no longer watched not to be fetched once the watcher stops")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func Test_diffMatch_canceled(t *testing.T) {
+	now := time.Now()
+	before := Match{ID: 1, Status: "not_started"}
+	after := Match{ID: 1, Status: "canceled"}
+
+	result := diffMatch(now, before, after)
+
+	assert.Equal(t, []MatchEvent{{Type: MatchCanceled, Time: now, Match: after, Previous: before}}, result)
+}
+
+func Test_diffMatch_unchanged(t *testing.T) {
+	match := Match{
+		ID:          1,
+		Status:      "running",
+		ScheduledAt: time.Now(),
+		Results:     []MatchResult{{TeamID: 10, Score: 1}},
+		Games:       []MatchGame{{ID: 1, Finished: true}},
+	}
+
+	assert.Empty(t, diffMatch(time.Now(), match, match))
+}